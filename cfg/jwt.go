@@ -1,7 +1,9 @@
 package cfg
 
 import (
-	"github.com/dgrijalva/jwt-go"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"os"
 	"strconv"
 	"time"
@@ -9,33 +11,85 @@ import (
 
 var JwtKey = []byte(os.Getenv("JWT_PRIVATE_KEY"))
 
-// Simpan token yang sudah digunakan dalam map
-var UsedTokens = make(map[string]bool)
-
-// payload untuk token
+// payload untuk token, jti ada di StandardClaims.Id dan dipakai untuk logout
 type Claims struct {
 	Username string `json:"username"`
 	UserID   int64  `json:"user_id"`
+	// SessionID adalah sesi login asal token, dicek Authmiddleware supaya sesi bisa dicabut
+	SessionID string `json:"sid"`
 	jwt.StandardClaims
 }
 
+// AccessAudience membedakan access token dari token lain yang ditandatangani dengan key yang sama
+// (misalnya token sementara 2FA), jadi verifier lain juga bisa memeriksa aud dan iss.
+const AccessAudience = "access"
+
+// TokenIssuer membaca JWT_ISSUER, default "todoGin".
+func TokenIssuer() string {
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "todoGin"
+}
+
+// VerifyAccessClaims dipakai Authmiddleware: token harus punya jti dan sid
+// (supaya bisa di-logout), serta audience dan issuer access token.
+func VerifyAccessClaims(claims *Claims) bool {
+	return claims.Id != "" && claims.SessionID != "" &&
+		claims.VerifyAudience(AccessAudience, true) &&
+		claims.VerifyIssuer(TokenIssuer(), true)
+}
+
+// default masa berlaku kalau TOKEN_TTL / REFRESH_TOKEN_TTL kosong atau tidak valid
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AccessTokenTTL membaca TOKEN_TTL dalam menit.
+func AccessTokenTTL() time.Duration {
+	return ttlFromEnv("TOKEN_TTL", time.Minute, DefaultAccessTokenTTL)
+}
+
+// RefreshTokenTTL membaca REFRESH_TOKEN_TTL dalam jam.
+func RefreshTokenTTL() time.Duration {
+	return ttlFromEnv("REFRESH_TOKEN_TTL", time.Hour, DefaultRefreshTokenTTL)
+}
+
+func ttlFromEnv(key string, unit, fallback time.Duration) time.Duration {
+	ttl, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || ttl <= 0 {
+		return fallback
+	}
+	return time.Duration(ttl) * unit
+}
+
 // fungsi untuk membuat token
-func CreateToken(username string, userID int64) (string, error) {
-	tokenTTL, _ := strconv.Atoi(os.Getenv("TOKEN_TTL"))
+func CreateToken(username string, userID int64, sessionID string) (string, error) {
 	// mengatur waktu kadaluwarsa token
-	//tokenTTLMinutes := tokenTTL / 60
-	expirationTime := time.Now().Add(time.Minute * time.Duration(tokenTTL))
+	expirationTime := time.Now().Add(AccessTokenTTL())
 
 	// membuat claims
 	claims := &Claims{
-		Username: username,
-		UserID:   userID,
+		Username:  username,
+		UserID:    userID,
+		SessionID: sessionID,
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
+			Issuer:    TokenIssuer(),
+			Audience:  AccessAudience,
 			ExpiresAt: expirationTime.Unix(),
 		},
 	}
 
-	// membuat token dengan signing method HS256 dan secret key
+	return signToken(claims)
+}
+
+// signToken memakai key RS256/EdDSA kalau dikonfigurasi, selain itu HS256 dengan secret key.
+func signToken(claims jwt.Claims) (string, error) {
+	if Keys != nil {
+		return Keys.Sign(claims)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(JwtKey)
 
@@ -43,12 +97,20 @@ func CreateToken(username string, userID int64) (string, error) {
 		return "", err
 	}
 
-	// simpan token yang di generate
-	//UsedTokens[tokenString] = false
-
 	return tokenString, nil
 }
 
+// Keyfunc dipakai Authmiddleware untuk memilih key verifikasi sesuai mode signing.
+func Keyfunc(token *jwt.Token) (interface{}, error) {
+	if Keys != nil {
+		return Keys.Keyfunc(token)
+	}
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	return JwtKey, nil
+}
+
 //func ParseToken(tokenString string) (string, int, error) {
 //	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 //		// Validasi tipe algoritma dan return secret key
@@ -0,0 +1,195 @@
+package cfg
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Keys berisi key RS256/EdDSA untuk token. Kalau nil (JWT_KEYS_DIR kosong),
+// token ditandatangani HS256 dengan JwtKey seperti sebelumnya.
+var Keys *KeySet
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet menyimpan satu key aktif untuk signing dan key lama (retired) yang
+// masih dipakai untuk verifikasi sampai token yang ditandatanganinya kadaluwarsa.
+type KeySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+// LoadSigningKeys membaca ulang JWT_PRIVATE_KEY, lalu key dari
+// JWT_KEYS_DIR kalau diisi. Dipanggil setelah .env dimuat karena variabel package
+// dibaca sebelum .env ada.
+func LoadSigningKeys() error {
+	JwtKey = []byte(os.Getenv("JWT_PRIVATE_KEY"))
+
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		keys, err := LoadKeySet(dir, os.Getenv("JWT_ACTIVE_KID"))
+		if err != nil {
+			return err
+		}
+		Keys = keys
+	}
+
+	if Keys == nil && len(JwtKey) == 0 {
+		return errors.New("JWT_PRIVATE_KEY or JWT_KEYS_DIR must be set")
+	}
+	return nil
+}
+
+// LoadKeySet membaca semua file *.pem di dir, nama file tanpa .pem menjadi kid.
+// File boleh berisi private key (RSA atau Ed25519) atau hanya public key untuk key yang sudah retired.
+// activeKID harus menunjuk ke private key.
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &KeySet{keys: make(map[string]*signingKey)}
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		key, err := loadKey(file, kid)
+		if err != nil {
+			return nil, fmt.Errorf("load key %s: %w", file, err)
+		}
+		keys.keys[kid] = key
+	}
+
+	active, ok := keys.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", activeKID, dir)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeKID)
+	}
+	keys.active = active
+	return keys, nil
+}
+
+func loadKey(file, kid string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return key, nil
+}
+
+// Sign menandatangani claims dengan key aktif dan mengisi header kid.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.active.method, claims)
+	token.Header["kid"] = k.active.kid
+	return token.SignedString(k.active.private)
+}
+
+// Keyfunc mencari public key dari header kid. Algoritma token harus sama
+// dengan algoritma key supaya token HS256 yang memakai public key sebagai secret ditolak.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %s", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
+
+// JWK adalah satu public key di /.well-known/jwks.json (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS mengembalikan public key aktif dan retired, urut berdasarkan kid.
+func (k *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if k == nil {
+		return jwks
+	}
+
+	for _, key := range k.keys {
+		jwk := JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+	return jwks
+}
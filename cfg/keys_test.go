@@ -0,0 +1,118 @@
+package cfg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writePEM(t *testing.T, dir, kid, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0600))
+}
+
+func testClaims() *Claims {
+	return &Claims{
+		Username:  "rey",
+		UserID:    1,
+		SessionID: "session",
+		StandardClaims: jwt.StandardClaims{
+			Id:        "jti",
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		},
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePEM(t, dir, "rsa-old", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "ed-new", "PRIVATE KEY", der)
+
+	oldKeys, err := LoadKeySet(dir, "rsa-old")
+	require.NoError(t, err)
+	oldToken, err := oldKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	// rotasi: key lama tinggal public key, key baru jadi aktif
+	der, err = x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "rsa-old", "PUBLIC KEY", der)
+
+	keys, err := LoadKeySet(dir, "ed-new")
+	require.NoError(t, err)
+	newToken, err := keys.Sign(testClaims())
+	require.NoError(t, err)
+
+	t.Run("NewKey", func(t *testing.T) {
+		claims := &Claims{}
+		token, err := jwt.ParseWithClaims(newToken, claims, keys.Keyfunc)
+		require.NoError(t, err)
+		assert.Equal(t, "ed-new", token.Header["kid"])
+		assert.Equal(t, "EdDSA", token.Method.Alg())
+		assert.Equal(t, "session", claims.SessionID)
+	})
+
+	t.Run("RetiredKey", func(t *testing.T) {
+		token, err := jwt.ParseWithClaims(oldToken, &Claims{}, keys.Keyfunc)
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", token.Method.Alg())
+	})
+
+	t.Run("UnknownKid", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, testClaims())
+		token.Header["kid"] = "missing"
+		signed, err := token.SignedString(edKey)
+		require.NoError(t, err)
+
+		_, err = jwt.ParseWithClaims(signed, &Claims{}, keys.Keyfunc)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("AlgorithmMismatch", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+		token.Header["kid"] = "rsa-old"
+		signed, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = jwt.ParseWithClaims(signed, &Claims{}, keys.Keyfunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("RetiredKeyCannotBeActive", func(t *testing.T) {
+		_, err := LoadKeySet(dir, "rsa-old")
+		assert.Error(t, err)
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		jwks := keys.JWKS()
+		require.Len(t, jwks.Keys, 2)
+
+		assert.Equal(t, "ed-new", jwks.Keys[0].Kid)
+		assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+		assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+		assert.NotEmpty(t, jwks.Keys[0].X)
+
+		assert.Equal(t, "rsa-old", jwks.Keys[1].Kid)
+		assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+		assert.NotEmpty(t, jwks.Keys[1].N)
+	})
+}
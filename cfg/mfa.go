@@ -0,0 +1,42 @@
+package cfg
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"time"
+)
+
+// MFAAudience menandai token sementara antara login password dan verifikasi 2FA,
+// token ini tidak bisa dipakai sebagai access token.
+const (
+	MFAAudience = "mfa"
+	MFATokenTTL = 5 * time.Minute
+)
+
+var ErrInvalidMFAToken = errors.New("invalid or expired mfa token")
+
+// CreateMFAToken membuat token sementara setelah password benar untuk user yang memakai 2FA.
+func CreateMFAToken(userID int64, now time.Time) (string, error) {
+	claims := &Claims{
+		UserID: userID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
+			Issuer:    TokenIssuer(),
+			Audience:  MFAAudience,
+			ExpiresAt: now.Add(MFATokenTTL).Unix(),
+		},
+	}
+	return signToken(claims)
+}
+
+// ParseMFAToken memverifikasi token sementara, access token biasa ditolak.
+func ParseMFAToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, Keyfunc)
+	if err != nil || !token.Valid || !claims.VerifyAudience(MFAAudience, true) ||
+		!claims.VerifyIssuer(TokenIssuer(), true) {
+		return nil, ErrInvalidMFAToken
+	}
+	return claims, nil
+}
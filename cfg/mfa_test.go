@@ -0,0 +1,73 @@
+package cfg
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMFAToken(t *testing.T) {
+	JwtKey = []byte("test-secret")
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		token, err := CreateMFAToken(7, time.Now())
+		require.NoError(t, err)
+
+		claims, err := ParseMFAToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), claims.UserID)
+		assert.NotEmpty(t, claims.Id)
+		assert.Empty(t, claims.SessionID)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token, err := CreateMFAToken(7, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		_, err = ParseMFAToken(token)
+		assert.ErrorIs(t, err, ErrInvalidMFAToken)
+	})
+
+	t.Run("AccessTokenRejected", func(t *testing.T) {
+		token, err := CreateToken("rey", 7, "session")
+		require.NoError(t, err)
+
+		_, err = ParseMFAToken(token)
+		assert.ErrorIs(t, err, ErrInvalidMFAToken)
+	})
+}
+
+func TestVerifyAccessClaims(t *testing.T) {
+	JwtKey = []byte("test-secret")
+
+	parse := func(t *testing.T, token string) *Claims {
+		claims := &Claims{}
+		_, err := jwt.ParseWithClaims(token, claims, Keyfunc)
+		require.NoError(t, err)
+		return claims
+	}
+
+	accessToken, err := CreateToken("rey", 7, "session")
+	require.NoError(t, err)
+	mfaToken, err := CreateMFAToken(7, time.Now())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		want   bool
+	}{
+		{"AccessToken", parse(t, accessToken), true},
+		{"MFAToken", parse(t, mfaToken), false},
+		{"WrongIssuer", &Claims{SessionID: "s", StandardClaims: jwt.StandardClaims{Id: "j", Audience: AccessAudience, Issuer: "other"}}, false},
+		{"NoAudience", &Claims{SessionID: "s", StandardClaims: jwt.StandardClaims{Id: "j", Issuer: TokenIssuer()}}, false},
+		{"NoSession", &Claims{StandardClaims: jwt.StandardClaims{Id: "j", Audience: AccessAudience, Issuer: TokenIssuer()}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyAccessClaims(tt.claims))
+		})
+	}
+}
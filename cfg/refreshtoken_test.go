@@ -0,0 +1,43 @@
+package cfg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, token, hash)
+	assert.Equal(t, hash, HashRefreshToken(token))
+	assert.Len(t, hash, 64)
+
+	other, _, err := NewRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestTokenTTL(t *testing.T) {
+	t.Run("Missing", func(t *testing.T) {
+		t.Setenv("TOKEN_TTL", "")
+		assert.Equal(t, DefaultAccessTokenTTL, AccessTokenTTL())
+	})
+
+	t.Run("Unparsable", func(t *testing.T) {
+		t.Setenv("TOKEN_TTL", "15m")
+		assert.Equal(t, DefaultAccessTokenTTL, AccessTokenTTL())
+	})
+
+	t.Run("Minutes", func(t *testing.T) {
+		t.Setenv("TOKEN_TTL", "5")
+		assert.Equal(t, 5*time.Minute, AccessTokenTTL())
+	})
+
+	t.Run("RefreshHours", func(t *testing.T) {
+		t.Setenv("REFRESH_TOKEN_TTL", "48")
+		assert.Equal(t, 48*time.Hour, RefreshTokenTTL())
+	})
+}
@@ -0,0 +1,70 @@
+package cfg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShareLinkKey dipakai untuk menandatangani public share link, diisi LoadShareLinkKey saat startup.
+var ShareLinkKey []byte
+
+var (
+	ErrInvalidShareLink = errors.New("invalid share link")
+	ErrShareLinkExpired = errors.New("share link expired")
+)
+
+// LoadShareLinkKey membaca SHARE_LINK_SECRET setelah .env dimuat. Key-nya sengaja
+// terpisah dari secret JWT, jadi server tidak jalan kalau belum diisi.
+func LoadShareLinkKey() error {
+	ShareLinkKey = []byte(os.Getenv("SHARE_LINK_SECRET"))
+	if len(ShareLinkKey) == 0 {
+		return errors.New("SHARE_LINK_SECRET must be set")
+	}
+	return nil
+}
+
+// SignShareLink membuat token "<id>.<expires>.<signature>" untuk share link.
+func SignShareLink(linkID int64, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", linkID, expiresAt.Unix())
+	return payload + "." + shareLinkSignature(payload)
+}
+
+// ParseShareLink memverifikasi signature token dan mengembalikan id share link.
+func ParseShareLink(token string, now time.Time) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, ErrInvalidShareLink
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(shareLinkSignature(payload))) {
+		return 0, ErrInvalidShareLink
+	}
+
+	linkID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidShareLink
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidShareLink
+	}
+	if !now.Before(time.Unix(expires, 0)) {
+		return 0, ErrShareLinkExpired
+	}
+
+	return linkID, nil
+}
+
+func shareLinkSignature(payload string) string {
+	mac := hmac.New(sha256.New, ShareLinkKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
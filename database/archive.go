@@ -0,0 +1,56 @@
+package database
+
+import (
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetArchivedByUser(userID int64, page, perPage int) ([]entity.Todolist, int64, error) {
+	var todos []entity.Todolist
+
+	var total int64
+	if err := t.DB.Model(&entity.Todolist{}).Scopes(t.ownerScope(userID)).Where("archived_at IS NOT NULL").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := t.DB.Preload("Attachments").Preload("Labels").
+		Scopes(t.ownerScope(userID)).Where("archived_at IS NOT NULL").
+		Order("archived_at DESC, id DESC").Offset(offset).Limit(perPage).
+		Find(&todos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return todos, total, t.decorate(todos)
+}
+
+// SetArchived mengarsipkan (archived=true) atau mengeluarkan todo dari arsip.
+func (t TodoRepository) SetArchived(todoID, userID int64, archived bool) (int64, error) {
+	var archivedAt interface{}
+	if archived {
+		archivedAt = time.Now()
+	}
+
+	result := t.DB.Model(&entity.Todolist{}).Scopes(t.ownerScope(userID)).Where("id = ?", todoID).
+		Update("archived_at", archivedAt)
+	return result.RowsAffected, result.Error
+}
+
+func (t TodoRepository) UpdateAutoArchiveDays(userID int64, days int) error {
+	return t.DB.Model(&entity.User{}).Where("id = ?", userID).Update("auto_archive_days", days).Error
+}
+
+// ArchiveDoneTodos mengarsipkan todo yang sudah done lebih lama dari
+// pengaturan auto_archive_days milik masing-masing user.
+func (t TodoRepository) ArchiveDoneTodos(now time.Time) (int64, error) {
+	result := t.DB.Exec(`UPDATE todolists t JOIN users u ON u.id = t.user_id
+		SET t.archived_at = ?
+		WHERE u.auto_archive_days > 0
+		  AND t.status = ?
+		  AND t.archived_at IS NULL
+		  AND t.deleted_at IS NULL
+		  AND t.status_changed_at < DATE_SUB(?, INTERVAL u.auto_archive_days DAY)`,
+		now, entity.StatusDone, now)
+	return result.RowsAffected, result.Error
+}
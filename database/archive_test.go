@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"todoGin/model/entity"
+)
+
+func TestArchiveDoneTodosCutoff(t *testing.T) {
+	var query string
+	var args []driver.NamedValue
+	db, _ := newFakeDB(t, func(q string, a []driver.NamedValue) fakeResult {
+		query, args = q, a
+		return fakeResult{affected: 3}
+	})
+	repo := TodoRepository{DB: db}
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	archived, err := repo.ArchiveDoneTodos(now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), archived)
+
+	// batas waktu dihitung per user dari auto_archive_days, user dengan 0 hari tidak ikut
+	query = strings.Join(strings.Fields(query), " ")
+	assert.Contains(t, query, "t.status_changed_at < DATE_SUB(?, INTERVAL u.auto_archive_days DAY)")
+	assert.Contains(t, query, "u.auto_archive_days > 0")
+	assert.Contains(t, query, "t.archived_at IS NULL")
+	assert.Contains(t, query, "t.deleted_at IS NULL")
+
+	require.Len(t, args, 3)
+	assert.Equal(t, now, args[0].Value, "archived_at is the run time")
+	assert.Equal(t, entity.StatusDone, args[1].Value)
+	assert.Equal(t, now, args[2].Value, "cutoff is measured from the run time")
+}
+
+func TestSetArchived(t *testing.T) {
+	var values []driver.Value
+	db, _ := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		if strings.HasPrefix(query, "UPDATE `todolists` SET `archived_at`") {
+			values = append(values, args[0].Value)
+		}
+		return fakeResult{affected: 1}
+	})
+	repo := TodoRepository{DB: db}
+
+	_, err := repo.SetArchived(4, 1, true)
+	require.NoError(t, err)
+	_, err = repo.SetArchived(4, 1, false)
+	require.NoError(t, err)
+
+	require.Len(t, values, 2)
+	assert.IsType(t, time.Time{}, values[0])
+	assert.Nil(t, values[1], "unarchive clears archived_at")
+}
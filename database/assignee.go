@@ -0,0 +1,129 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetUserByID(userID int64) (*entity.User, error) {
+	var user entity.User
+	result := t.DB.Where("id = ?", userID).First(&user)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &user, result.Error
+}
+
+// IsAssignable mengecek apakah userID boleh jadi assignee todo: pemilik todo, user yang
+// mendapat share todo atau project-nya, atau anggota workspace tempat todo berada.
+func (t TodoRepository) IsAssignable(todo *entity.Todolist, userID int64) (bool, error) {
+	if todo.UserID == userID {
+		return true, nil
+	}
+
+	var count int64
+	if todo.WorkspaceID != nil {
+		err := t.DB.Model(&entity.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ?", *todo.WorkspaceID, userID).
+			Count(&count).Error
+		if err != nil || count > 0 {
+			return count > 0, err
+		}
+	}
+
+	err := t.DB.Model(&entity.TodoShare{}).
+		Where("todo_id = ? AND user_id = ?", todo.ID, userID).
+		Count(&count).Error
+	if err != nil || count > 0 || todo.ProjectID == nil {
+		return count > 0, err
+	}
+
+	err = t.DB.Model(&entity.ProjectShare{}).
+		Where("project_id = ? AND user_id = ?", *todo.ProjectID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AssignTodo mengganti assignee todo, nil berarti assignment dihapus.
+func (t TodoRepository) AssignTodo(todoID int64, assigneeID *int64) error {
+	return t.DB.Model(&entity.Todolist{}).Where("id = ?", todoID).Update("assignee_id", assigneeID).Error
+}
+
+// GetAssignedToUser mengambil todo yang di-assign ke userID, dengan workspace aktif
+// hanya todo di workspace itu.
+func (t TodoRepository) GetAssignedToUser(userID int64) ([]entity.Todolist, error) {
+	var todos []entity.Todolist
+	err := t.DB.Preload("Attachments").Preload("Labels").
+		Scopes(t.workspaceScope).
+		Where("assignee_id = ? AND archived_at IS NULL", userID).
+		Order("due_at IS NULL, due_at ASC, id ASC").
+		Find(&todos).Error
+	if err != nil {
+		return nil, err
+	}
+	return todos, t.decorate(todos)
+}
+
+// fillAssignees mengisi username assignee tanpa ikut membawa data user lainnya.
+func (t TodoRepository) fillAssignees(todos []entity.Todolist) error {
+	var ids []int64
+	for i := range todos {
+		if todos[i].AssigneeID != nil {
+			ids = append(ids, *todos[i].AssigneeID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var users []struct {
+		ID       int64
+		Username string
+	}
+	if err := t.DB.Table("users").Select("id, username").Where("id IN ?", ids).Scan(&users).Error; err != nil {
+		return err
+	}
+
+	usernames := make(map[int64]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	for i := range todos {
+		if todos[i].AssigneeID != nil {
+			todos[i].AssigneeUsername = usernames[*todos[i].AssigneeID]
+		}
+	}
+
+	return nil
+}
+
+// fillOwners mengisi username pemilik todo untuk tampilan lintas akun.
+func (t TodoRepository) fillOwners(todos []entity.Todolist) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].UserID
+	}
+
+	var users []struct {
+		ID       int64
+		Username string
+	}
+	if err := t.DB.Table("users").Select("id, username").Where("id IN ?", ids).Scan(&users).Error; err != nil {
+		return err
+	}
+
+	usernames := make(map[int64]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	for i := range todos {
+		todos[i].OwnerUsername = usernames[todos[i].UserID]
+	}
+
+	return nil
+}
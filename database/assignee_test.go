@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+)
+
+func TestIsAssignable(t *testing.T) {
+	// user 3 anggota workspace 5, user 4 mendapat share todo, user 9 tidak punya hubungan apa pun
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		count := int64(0)
+		switch {
+		case strings.Contains(query, "`workspace_members`") && args[1].Value == int64(3):
+			count = 1
+		case strings.Contains(query, "`todo_shares`") && args[1].Value == int64(4):
+			count = 1
+		}
+		return fakeResult{columns: []string{"count(*)"}, rows: [][]driver.Value{{count}}}
+	})
+	repo := TodoRepository{DB: db}
+	workspaceID := int64(5)
+	todo := &entity.Todolist{ID: 1, UserID: 2, WorkspaceID: &workspaceID}
+
+	ok, err := repo.IsAssignable(todo, 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, fake.Queries(), "owner is always assignable")
+
+	ok, err = repo.IsAssignable(todo, 3)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.IsAssignable(todo, 4)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.IsAssignable(todo, 9)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
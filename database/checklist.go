@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"todoGin/model/entity"
+)
+
+// CreateChecklistItem menambahkan item di urutan paling akhir checklist todo.
+func (t TodoRepository) CreateChecklistItem(item *entity.ChecklistItem) error {
+	var lastOrder int64
+	t.DB.Model(&entity.ChecklistItem{}).Where("todo_id = ?", item.TodoID).
+		Select("COALESCE(MAX(item_order), 0)").Scan(&lastOrder)
+	item.ItemOrder = lastOrder + 1
+
+	return t.DB.Create(item).Error
+}
+
+func (t TodoRepository) GetChecklistItem(itemID, todoID int64) (*entity.ChecklistItem, error) {
+	var item entity.ChecklistItem
+	result := t.DB.Where("id = ? AND todo_id = ?", itemID, todoID).First(&item)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &item, result.Error
+}
+
+func (t TodoRepository) UpdateChecklistItem(itemID, todoID int64, updates map[string]interface{}) error {
+	return t.DB.Model(&entity.ChecklistItem{}).Where("id = ? AND todo_id = ?", itemID, todoID).Updates(updates).Error
+}
+
+func (t TodoRepository) DeleteChecklistItem(itemID, todoID int64) (int64, error) {
+	result := t.DB.Where("id = ? AND todo_id = ?", itemID, todoID).Delete(&entity.ChecklistItem{})
+	return result.RowsAffected, result.Error
+}
+
+// fillProgress menghitung progress checklist (done/total) untuk setiap todo sekaligus.
+func (t TodoRepository) fillProgress(todos []entity.Todolist) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].ID
+	}
+
+	var rows []struct {
+		TodoID int64
+		Done   int64
+		Total  int64
+	}
+	err := t.DB.Model(&entity.ChecklistItem{}).
+		Select("todo_id, SUM(done) AS done, COUNT(*) AS total").
+		Where("todo_id IN ?", ids).Group("todo_id").Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	progress := make(map[int64]entity.ChecklistProgress, len(rows))
+	for _, row := range rows {
+		progress[row.TodoID] = entity.ChecklistProgress{Done: row.Done, Total: row.Total}
+	}
+	for i := range todos {
+		todos[i].Progress = progress[todos[i].ID]
+	}
+
+	return nil
+}
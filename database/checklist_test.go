@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"todoGin/model/entity"
+)
+
+func TestFillProgress(t *testing.T) {
+	t.Run("RollUpPerTodo", func(t *testing.T) {
+		db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+			return fakeResult{
+				columns: []string{"todo_id", "done", "total"},
+				rows: [][]driver.Value{
+					{int64(1), int64(2), int64(3)},
+					{int64(3), int64(0), int64(1)},
+				},
+			}
+		})
+		repo := TodoRepository{DB: db}
+
+		todos := []entity.Todolist{{ID: 1}, {ID: 2}, {ID: 3}}
+		require.NoError(t, repo.fillProgress(todos))
+
+		assert.Equal(t, entity.ChecklistProgress{Done: 2, Total: 3}, todos[0].Progress)
+		assert.Equal(t, entity.ChecklistProgress{}, todos[1].Progress, "todo without checklist")
+		assert.Equal(t, entity.ChecklistProgress{Done: 0, Total: 1}, todos[2].Progress)
+
+		// progress semua todo dihitung dengan satu query, bukan satu query per todo
+		queries := fake.Queries()
+		require.Len(t, queries, 1)
+		assert.Contains(t, queries[0], "GROUP BY `todo_id`")
+	})
+
+	t.Run("NoTodos", func(t *testing.T) {
+		db, fake := newFakeDB(t, nil)
+		repo := TodoRepository{DB: db}
+
+		require.NoError(t, repo.fillProgress(nil))
+		assert.Empty(t, fake.Queries())
+	})
+}
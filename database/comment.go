@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetComments(todoID int64, page, perPage int) ([]entity.Comment, int64, error) {
+	var comments []entity.Comment
+
+	var total int64
+	if err := t.DB.Model(&entity.Comment{}).Where("todo_id = ?", todoID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := t.commentQuery().Where("c.todo_id = ?", todoID).
+		Order("c.id ASC").Offset(offset).Limit(perPage).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (t TodoRepository) GetComment(commentID, todoID int64) (*entity.Comment, error) {
+	var comment entity.Comment
+	result := t.commentQuery().Where("c.id = ? AND c.todo_id = ?", commentID, todoID).First(&comment)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &comment, result.Error
+}
+
+func (t TodoRepository) CreateComment(comment *entity.Comment) error {
+	return t.DB.Create(comment).Error
+}
+
+func (t TodoRepository) UpdateComment(commentID int64, body string) error {
+	return t.DB.Model(&entity.Comment{}).Where("id = ?", commentID).
+		Updates(map[string]interface{}{"body": body, "edited_at": time.Now()}).Error
+}
+
+func (t TodoRepository) DeleteComment(commentID int64) (int64, error) {
+	result := t.DB.Where("id = ?", commentID).Delete(&entity.Comment{})
+	return result.RowsAffected, result.Error
+}
+
+// commentQuery menyertakan username penulis di setiap comment.
+func (t TodoRepository) commentQuery() *gorm.DB {
+	return t.DB.Table("todo_comments c").
+		Select("c.*, u.username AS author_username").
+		Joins("JOIN users u ON u.id = c.user_id")
+}
+
+// fillCommentCount menghitung jumlah comment untuk setiap todo sekaligus.
+func (t TodoRepository) fillCommentCount(todos []entity.Todolist) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].ID
+	}
+
+	var rows []struct {
+		TodoID int64
+		Total  int64
+	}
+	err := t.DB.Model(&entity.Comment{}).
+		Select("todo_id, COUNT(*) AS total").
+		Where("todo_id IN ?", ids).Group("todo_id").Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.TodoID] = row.Total
+	}
+	for i := range todos {
+		todos[i].CommentCount = counts[todos[i].ID]
+	}
+
+	return nil
+}
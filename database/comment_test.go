@@ -0,0 +1,21 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetCommentsCountError(t *testing.T) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		return fakeResult{err: errors.New("connection refused")}
+	})
+
+	comments, total, err := TodoRepository{DB: db}.GetComments(1, 1, 10)
+
+	assert.EqualError(t, err, "connection refused")
+	assert.Nil(t, comments)
+	assert.Zero(t, total)
+	assert.Len(t, fake.Queries(), 1, "comment list is not read after a failed count")
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDSN(t *testing.T) {
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "root")
+	t.Setenv("DB_PASS", "rahasia")
+	t.Setenv("DB_NAME", "Gin_todo")
+	t.Setenv("DB_PORT", "3306")
+
+	// koneksi aplikasi tidak boleh menerima beberapa statement sekaligus
+	assert.Equal(t, "root:rahasia@tcp(localhost:3306)/Gin_todo?charset=utf8mb4&parseTime=True&loc=Local", dsn(""))
+	assert.Contains(t, dsn("&multiStatements=true"), "loc=Local&multiStatements=true")
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// AddDependency menandai todoID diblok oleh blockedByID. Relasi milik user dikunci dan dicek
+// ulang di transaksi yang sama, jadi dua request paralel tidak bisa membuat siklus bersama-sama.
+// Kalau relasi baru membuat siklus, dikembalikan repository.ErrDependencyCycle.
+func (t TodoRepository) AddDependency(todoID, blockedByID, userID int64) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		var edges []entity.TodoDependency
+		err := t.dependencyEdges(tx, userID).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scan(&edges).Error
+		if err != nil {
+			return err
+		}
+		if createsCycle(edges, todoID, blockedByID) {
+			return repository.ErrDependencyCycle
+		}
+
+		return tx.Exec("INSERT IGNORE INTO todo_dependencies (todo_id, blocked_by_id) VALUES (?, ?)", todoID, blockedByID).Error
+	})
+}
+
+func (t TodoRepository) RemoveDependency(todoID, blockedByID int64) (int64, error) {
+	result := t.DB.Where("todo_id = ? AND blocked_by_id = ?", todoID, blockedByID).Delete(&entity.TodoDependency{})
+	return result.RowsAffected, result.Error
+}
+
+// dependencyEdges menyiapkan query semua relasi blocked-by antar todo milik user
+// (atau di workspace yang aktif).
+func (t TodoRepository) dependencyEdges(tx *gorm.DB, userID int64) *gorm.DB {
+	cond, args := ownerCond("t.", userID, t.WorkspaceID)
+	return tx.Table("todo_dependencies d").
+		Select("d.todo_id, d.blocked_by_id, d.created_at").
+		Joins("JOIN todolists t ON t.id = d.todo_id").
+		Where(cond, args...)
+}
+
+// createsCycle mengecek apakah edge todoID -> blockedByID membuat siklus,
+// yaitu kalau todoID sudah bisa dicapai dari blockedByID lewat edge yang ada.
+func createsCycle(edges []entity.TodoDependency, todoID, blockedByID int64) bool {
+	graph := make(map[int64][]int64)
+	for _, edge := range edges {
+		graph[edge.TodoID] = append(graph[edge.TodoID], edge.BlockedByID)
+	}
+
+	visited := make(map[int64]bool)
+	stack := []int64{blockedByID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == todoID {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		stack = append(stack, graph[current]...)
+	}
+
+	return false
+}
+
+// fillBlocked menandai todo yang masih punya blocker belum selesai. Blocker yang
+// cancelled dianggap tidak lagi menghalangi, begitu juga blocker yang ada di trash.
+func (t TodoRepository) fillBlocked(todos []entity.Todolist) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].ID
+	}
+
+	var edges []entity.TodoDependency
+	err := t.DB.Table("todo_dependencies d").
+		Select("d.todo_id, d.blocked_by_id").
+		Joins("JOIN todolists b ON b.id = d.blocked_by_id").
+		Where("d.todo_id IN ? AND b.status NOT IN ? AND b.deleted_at IS NULL", ids, entity.ClosedStatuses).
+		Scan(&edges).Error
+	if err != nil {
+		return err
+	}
+
+	blockers := make(map[int64][]int64)
+	for _, edge := range edges {
+		blockers[edge.TodoID] = append(blockers[edge.TodoID], edge.BlockedByID)
+	}
+	for i := range todos {
+		todos[i].BlockedBy = blockers[todos[i].ID]
+		todos[i].Blocked = len(todos[i].BlockedBy) > 0
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// blockedBy membuat edge dari pasangan (todo, blocker).
+func blockedBy(pairs ...[2]int64) []entity.TodoDependency {
+	edges := make([]entity.TodoDependency, len(pairs))
+	for i, pair := range pairs {
+		edges[i] = entity.TodoDependency{TodoID: pair[0], BlockedByID: pair[1]}
+	}
+	return edges
+}
+
+func TestCreatesCycle(t *testing.T) {
+	// 1 diblok 2, 2 diblok 3
+	chain := blockedBy([2]int64{1, 2}, [2]int64{2, 3})
+
+	assert.False(t, createsCycle(nil, 1, 2), "first edge")
+	assert.True(t, createsCycle(nil, 1, 1), "self dependency")
+	assert.True(t, createsCycle(blockedBy([2]int64{1, 2}), 2, 1), "direct back edge")
+	assert.True(t, createsCycle(chain, 3, 1), "back edge through the chain")
+	assert.False(t, createsCycle(chain, 3, 4), "extending the chain")
+	assert.False(t, createsCycle(chain, 1, 3), "shortcut in the same direction")
+
+	// 1 diblok 2 dan 3, keduanya diblok 4: bentuk diamond bukan siklus
+	diamond := blockedBy([2]int64{1, 2}, [2]int64{1, 3}, [2]int64{2, 4}, [2]int64{3, 4})
+	assert.False(t, createsCycle(diamond, 1, 4))
+	assert.True(t, createsCycle(diamond, 4, 1))
+
+	// siklus lama di data tidak boleh membuat pengecekan berputar terus
+	loop := blockedBy([2]int64{5, 6}, [2]int64{6, 5})
+	assert.False(t, createsCycle(loop, 1, 5))
+}
+
+// dependencyDB menjawab query edge dengan rows, INSERT selalu berhasil.
+func dependencyDB(t *testing.T, rows ...[2]int64) (*fakeDB, TodoRepository) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		if strings.HasPrefix(query, "SELECT d.todo_id") {
+			result := fakeResult{columns: []string{"todo_id", "blocked_by_id"}}
+			for _, row := range rows {
+				result.rows = append(result.rows, []driver.Value{row[0], row[1]})
+			}
+			return result
+		}
+		return fakeResult{affected: 1}
+	})
+	return fake, TodoRepository{DB: db}
+}
+
+func TestAddDependencyLocksEdges(t *testing.T) {
+	fake, repo := dependencyDB(t, [2]int64{2, 3})
+
+	require.NoError(t, repo.AddDependency(1, 2, 7))
+
+	queries := fake.Queries()
+	require.Len(t, queries, 4)
+	assert.Equal(t, "BEGIN", queries[0])
+	// edge dibaca dengan lock supaya request lain menunggu sampai insert ini selesai
+	assert.True(t, strings.HasSuffix(queries[1], "FOR UPDATE"), queries[1])
+	assert.Contains(t, queries[2], "INSERT IGNORE INTO todo_dependencies")
+	assert.Equal(t, "COMMIT", queries[3])
+}
+
+func TestAddDependencyRejectsCycle(t *testing.T) {
+	// 2 sudah diblok 1, jadi 1 tidak boleh diblok 2
+	fake, repo := dependencyDB(t, [2]int64{2, 1})
+
+	err := repo.AddDependency(1, 2, 7)
+	assert.ErrorIs(t, err, repository.ErrDependencyCycle)
+
+	queries := fake.Queries()
+	assert.Equal(t, "ROLLBACK", queries[len(queries)-1])
+	assert.NotContains(t, strings.Join(queries, "\n"), "INSERT")
+}
@@ -0,0 +1,26 @@
+package database
+
+import "todoGin/model/entity"
+
+func (t TodoRepository) AddHistory(history *entity.TodoHistory) error {
+	return t.DB.Create(history).Error
+}
+
+func (t TodoRepository) GetHistory(todoID int64, page, perPage int) ([]entity.TodoHistory, int64, error) {
+	var histories []entity.TodoHistory
+
+	var total int64
+	if err := t.DB.Model(&entity.TodoHistory{}).Where("todo_id = ?", todoID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := t.DB.Where("todo_id = ?", todoID).
+		Order("id DESC").Offset(offset).Limit(perPage).
+		Find(&histories).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetLabelsByUser(userID int64) ([]entity.Label, error) {
+	var labels []entity.Label
+	result := t.DB.Where("user_id = ?", userID).Order("name ASC").Find(&labels)
+	return labels, result.Error
+}
+
+func (t TodoRepository) GetLabelByID(labelID, userID int64) (*entity.Label, error) {
+	var label entity.Label
+	result := t.DB.Where("id = ? AND user_id = ?", labelID, userID).First(&label)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &label, result.Error
+}
+
+func (t TodoRepository) GetLabelByName(userID int64, name string) (*entity.Label, error) {
+	var label entity.Label
+	result := t.DB.Where("user_id = ? AND name = ?", userID, name).First(&label)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &label, result.Error
+}
+
+func (t TodoRepository) CreateLabel(label *entity.Label) error {
+	return t.DB.Create(label).Error
+}
+
+func (t TodoRepository) UpdateLabel(labelID, userID int64, updates map[string]interface{}) error {
+	return t.DB.Model(&entity.Label{}).Where("id = ? AND user_id = ?", labelID, userID).Updates(updates).Error
+}
+
+func (t TodoRepository) DeleteLabel(labelID, userID int64) (int64, error) {
+	result := t.DB.Where("id = ? AND user_id = ?", labelID, userID).Delete(&entity.Label{})
+	return result.RowsAffected, result.Error
+}
+
+// AttachLabel menempelkan label ke todo, kalau sudah menempel tidak terjadi apa-apa.
+func (t TodoRepository) AttachLabel(todoID, labelID int64) error {
+	return t.DB.Exec("INSERT IGNORE INTO todolist_labels (todolist_id, label_id) VALUES (?, ?)", todoID, labelID).Error
+}
+
+func (t TodoRepository) DetachLabel(todoID, labelID int64) (int64, error) {
+	result := t.DB.Exec("DELETE FROM todolist_labels WHERE todolist_id = ? AND label_id = ?", todoID, labelID)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,4 @@
+ALTER TABLE todolists
+    DROP INDEX idx_todolists_user_due,
+    DROP COLUMN due_at,
+    DROP COLUMN start_at;
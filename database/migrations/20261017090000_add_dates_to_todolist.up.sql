@@ -0,0 +1,4 @@
+ALTER TABLE todolists
+    ADD COLUMN start_at DATETIME NULL AFTER status,
+    ADD COLUMN due_at DATETIME NULL AFTER start_at,
+    ADD INDEX idx_todolists_user_due (user_id, due_at);
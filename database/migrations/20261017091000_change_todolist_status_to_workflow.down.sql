@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS todo_status_transitions;
+
+ALTER TABLE todolists ADD COLUMN bool_status TINYINT DEFAULT 0 AFTER status;
+
+UPDATE todolists SET bool_status = IF(status = 'done', 1, 0);
+
+ALTER TABLE todolists DROP COLUMN status, DROP COLUMN status_changed_at;
+
+ALTER TABLE todolists RENAME COLUMN bool_status TO status;
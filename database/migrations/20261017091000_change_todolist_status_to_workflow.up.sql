@@ -0,0 +1,20 @@
+ALTER TABLE todolists
+    ADD COLUMN workflow_status VARCHAR(20) NOT NULL DEFAULT 'todo' AFTER status,
+    ADD COLUMN status_changed_at DATETIME NULL AFTER workflow_status;
+
+UPDATE todolists SET workflow_status = IF(status = 1, 'done', 'todo');
+
+ALTER TABLE todolists DROP COLUMN status;
+
+ALTER TABLE todolists RENAME COLUMN workflow_status TO status;
+
+CREATE TABLE todo_status_transitions
+(
+    id bigint NOT NULL AUTO_INCREMENT,
+    todo_id bigint NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    transitioned_at timestamp default current_timestamp,
+    PRIMARY KEY (id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS todolist_labels;
+
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,19 @@
+CREATE TABLE labels
+(
+    id bigint NOT NULL AUTO_INCREMENT,
+    user_id bigint NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(20) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    UNIQUE KEY (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE todolist_labels
+(
+    todolist_id bigint NOT NULL,
+    label_id bigint NOT NULL,
+    PRIMARY KEY (todolist_id, label_id),
+    FOREIGN KEY (todolist_id) REFERENCES todolists(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+);
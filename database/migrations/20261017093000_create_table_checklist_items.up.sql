@@ -0,0 +1,11 @@
+CREATE TABLE checklist_items
+(
+    id bigint NOT NULL AUTO_INCREMENT,
+    todo_id bigint NOT NULL,
+    title VARCHAR(300) NOT NULL,
+    done TINYINT(1) NOT NULL DEFAULT 0,
+    item_order int NOT NULL,
+    completed_at DATETIME NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE todolists
+    DROP COLUMN recurrence;
@@ -0,0 +1,2 @@
+ALTER TABLE todolists
+    ADD COLUMN recurrence VARCHAR(255) NOT NULL DEFAULT '' AFTER due_at;
@@ -0,0 +1,5 @@
+ALTER TABLE todolists
+    DROP FOREIGN KEY fk_todolists_project,
+    DROP COLUMN project_id;
+
+DROP TABLE IF EXISTS projects;
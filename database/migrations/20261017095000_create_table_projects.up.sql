@@ -0,0 +1,16 @@
+CREATE TABLE projects
+(
+    id bigint NOT NULL AUTO_INCREMENT,
+    user_id bigint NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    color VARCHAR(20) NOT NULL DEFAULT '',
+    archived TINYINT(1) NOT NULL DEFAULT 0,
+    sort_order int NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE todolists
+    ADD COLUMN project_id bigint NULL AFTER user_id,
+    ADD CONSTRAINT fk_todolists_project FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL;
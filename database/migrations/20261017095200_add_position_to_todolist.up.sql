@@ -0,0 +1,5 @@
+ALTER TABLE todolists
+    ADD COLUMN position VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '' AFTER project_id,
+    ADD INDEX idx_todolists_user_position (user_id, position);
+
+UPDATE todolists SET position = CONCAT(LPAD(CONV(id, 10, 36), 10, '0'), 'V');
@@ -0,0 +1,3 @@
+ALTER TABLE todolists
+    DROP COLUMN estimate_minutes,
+    DROP COLUMN priority;
@@ -0,0 +1,3 @@
+ALTER TABLE todolists
+    ADD COLUMN priority TINYINT NOT NULL DEFAULT 4 AFTER recurrence,
+    ADD COLUMN estimate_minutes INT NULL AFTER priority;
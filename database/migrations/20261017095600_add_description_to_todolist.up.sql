@@ -0,0 +1,2 @@
+ALTER TABLE todolists
+    ADD COLUMN description TEXT NULL AFTER title;
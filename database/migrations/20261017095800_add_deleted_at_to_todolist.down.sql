@@ -0,0 +1,3 @@
+ALTER TABLE todolists
+    DROP INDEX idx_todolists_deleted_at,
+    DROP COLUMN deleted_at;
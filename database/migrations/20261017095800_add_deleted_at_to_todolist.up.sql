@@ -0,0 +1,3 @@
+ALTER TABLE todolists
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX idx_todolists_deleted_at (deleted_at);
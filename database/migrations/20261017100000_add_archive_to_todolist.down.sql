@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN auto_archive_days;
+
+ALTER TABLE todolists
+    DROP INDEX idx_todolists_user_archived,
+    DROP COLUMN archived_at;
@@ -0,0 +1,6 @@
+ALTER TABLE todolists
+    ADD COLUMN archived_at DATETIME NULL AFTER position,
+    ADD INDEX idx_todolists_user_archived (user_id, archived_at);
+
+ALTER TABLE users
+    ADD COLUMN auto_archive_days INT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+CREATE TABLE todo_dependencies
+(
+    todo_id bigint NOT NULL,
+    blocked_by_id bigint NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, blocked_by_id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_by_id) REFERENCES todolists(id) ON DELETE CASCADE
+);
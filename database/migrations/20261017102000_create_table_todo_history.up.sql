@@ -0,0 +1,12 @@
+CREATE TABLE todo_history
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    todo_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    action varchar(30) NOT NULL,
+    old_values JSON NULL,
+    new_values JSON NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_todo_history_todo_id (todo_id, id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+CREATE TABLE todo_comments
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    todo_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    edited_at TIMESTAMP NULL,
+    INDEX idx_todo_comments_todo_id (todo_id, id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
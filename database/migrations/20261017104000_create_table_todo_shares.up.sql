@@ -0,0 +1,11 @@
+CREATE TABLE todo_shares
+(
+    todo_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, user_id),
+    INDEX idx_todo_shares_user_id (user_id),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE todolists
+    DROP FOREIGN KEY fk_todolists_assignee,
+    DROP INDEX idx_todolists_assignee_id,
+    DROP COLUMN assignee_id;
@@ -0,0 +1,4 @@
+ALTER TABLE todolists
+    ADD COLUMN assignee_id bigint NULL AFTER project_id,
+    ADD INDEX idx_todolists_assignee_id (assignee_id),
+    ADD CONSTRAINT fk_todolists_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL;
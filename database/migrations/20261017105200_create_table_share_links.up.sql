@@ -0,0 +1,13 @@
+CREATE TABLE share_links
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    todo_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    password_hash varchar(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_share_links_user_id (user_id, expires_at),
+    FOREIGN KEY (todo_id) REFERENCES todolists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+ALTER TABLE projects
+    DROP FOREIGN KEY fk_projects_workspace,
+    DROP COLUMN workspace_id;
+
+ALTER TABLE todolists
+    DROP FOREIGN KEY fk_todolists_workspace,
+    DROP COLUMN workspace_id;
+
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
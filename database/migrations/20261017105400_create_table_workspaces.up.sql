@@ -0,0 +1,42 @@
+CREATE TABLE workspaces
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    owner_id bigint NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE workspace_members
+(
+    workspace_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id),
+    INDEX idx_workspace_members_user_id (user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE workspace_invitations
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    workspace_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'member',
+    invited_by bigint NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP NULL,
+    INDEX idx_workspace_invitations_user_id (user_id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE todolists
+    ADD COLUMN workspace_id bigint NULL AFTER user_id,
+    ADD CONSTRAINT fk_todolists_workspace FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE projects
+    ADD COLUMN workspace_id bigint NULL AFTER user_id,
+    ADD CONSTRAINT fk_projects_workspace FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
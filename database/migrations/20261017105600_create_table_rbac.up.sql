@@ -0,0 +1,34 @@
+CREATE TABLE roles
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    name varchar(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE permissions
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    name varchar(100) NOT NULL UNIQUE
+);
+
+CREATE TABLE role_permissions
+(
+    role_id bigint NOT NULL,
+    permission_id bigint NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE,
+    FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles
+(
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    PRIMARY KEY (user_id, role_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+);
+
+INSERT INTO roles (name) VALUES ('admin');
+INSERT INTO permissions (name) VALUES ('todos:read_all'), ('users:read'), ('roles:manage');
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'admin';
@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name = 'users:manage';
+
+ALTER TABLE users
+    DROP COLUMN password_reset_required,
+    DROP COLUMN disabled_at;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN disabled_at DATETIME NULL,
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO permissions (name) VALUES ('users:manage');
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'admin' AND p.name = 'users:manage';
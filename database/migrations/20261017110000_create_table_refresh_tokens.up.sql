@@ -0,0 +1,13 @@
+CREATE TABLE refresh_tokens
+(
+    id bigint PRIMARY KEY AUTO_INCREMENT,
+    user_id bigint NOT NULL,
+    family_id char(36) NOT NULL,
+    token_hash char(64) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_refresh_tokens_family_id (family_id),
+    INDEX idx_refresh_tokens_expires_at (expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
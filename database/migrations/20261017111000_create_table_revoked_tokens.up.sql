@@ -0,0 +1,9 @@
+CREATE TABLE revoked_tokens
+(
+    jti char(36) PRIMARY KEY,
+    user_id bigint NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_revoked_tokens_expires_at (expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
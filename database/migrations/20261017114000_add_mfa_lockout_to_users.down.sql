@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN totp_last_step,
+    DROP COLUMN mfa_locked_until,
+    DROP COLUMN mfa_failed_attempts;
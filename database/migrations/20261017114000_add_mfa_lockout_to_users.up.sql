@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN mfa_failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN mfa_locked_until DATETIME NULL,
+    ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE project_shares
+(
+    project_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, user_id),
+    INDEX idx_project_shares_user_id (user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"todoGin/model/entity"
+)
+
+// lastPosition mengambil rank terbesar di dalam scope (milik user atau workspace),
+// string kosong kalau belum ada todo.
+func lastPosition(tx *gorm.DB, scope func(db *gorm.DB) *gorm.DB) (string, error) {
+	var position string
+	err := tx.Model(&entity.Todolist{}).Scopes(scope).
+		Select("COALESCE(MAX(position), '')").Scan(&position).Error
+	return position, err
+}
+
+// MoveTodo memindahkan todo tepat sebelum atau sesudah todo target.
+// Hanya baris todo yang dipindah yang ditulis, kecuali rank sudah terlalu panjang
+// sehingga posisi milik user perlu di-rebalance sekali.
+func (t TodoRepository) MoveTodo(todoID, userID, targetID int64, after bool) (string, error) {
+	var position string
+	scope := t.ownerScope(userID)
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		position, err = positionNextTo(tx, scope, todoID, targetID, after)
+		if err != nil {
+			return err
+		}
+
+		if len(position) > maxRankLength {
+			if err := rebalancePositions(tx, scope); err != nil {
+				return err
+			}
+			if position, err = positionNextTo(tx, scope, todoID, targetID, after); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.Todolist{}).Scopes(scope).Where("id = ?", todoID).
+			Update("position", position).Error
+	})
+
+	return position, err
+}
+
+func positionNextTo(tx *gorm.DB, scope func(db *gorm.DB) *gorm.DB, todoID, targetID int64, after bool) (string, error) {
+	var target entity.Todolist
+	if err := tx.Scopes(scope).Where("id = ?", targetID).First(&target).Error; err != nil {
+		return "", err
+	}
+
+	var neighbour []string
+	query := tx.Model(&entity.Todolist{}).Scopes(scope).Where("id <> ?", todoID)
+	if after {
+		query = query.Where("position > ?", target.Position).Order("position ASC")
+	} else {
+		query = query.Where("position < ?", target.Position).Order("position DESC")
+	}
+	if err := query.Limit(1).Pluck("position", &neighbour).Error; err != nil {
+		return "", err
+	}
+
+	other := ""
+	if len(neighbour) > 0 {
+		other = neighbour[0]
+	}
+	if after {
+		return rankBetween(target.Position, other), nil
+	}
+	return rankBetween(other, target.Position), nil
+}
+
+// rebalancePositions menulis ulang semua rank di dalam scope dengan jarak yang rata.
+func rebalancePositions(tx *gorm.DB, scope func(db *gorm.DB) *gorm.DB) error {
+	var ids []int64
+	err := tx.Model(&entity.Todolist{}).Scopes(scope).
+		Order("position ASC, id ASC").Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for i, position := range evenRanks(len(ids)) {
+		if err := tx.Model(&entity.Todolist{}).Where("id = ?", ids[i]).Update("position", position).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
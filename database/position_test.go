@@ -0,0 +1,41 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestMoveTodoWritesOnlyMovedRow(t *testing.T) {
+	var updatedPosition string
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "SELECT * FROM `todolists`"):
+			// todo target (id 2) ada di posisi "K"
+			return fakeResult{columns: []string{"id", "user_id", "position"}, rows: [][]driver.Value{{int64(2), int64(1), "K"}}}
+		case strings.HasPrefix(query, "SELECT `position` FROM `todolists`"):
+			// tetangga sesudah target ada di posisi "V"
+			return fakeResult{columns: []string{"position"}, rows: [][]driver.Value{{"V"}}}
+		case strings.HasPrefix(query, "UPDATE `todolists` SET `position`"):
+			updatedPosition = args[0].Value.(string)
+		}
+		return fakeResult{}
+	})
+	repo := TodoRepository{DB: db}
+
+	position, err := repo.MoveTodo(5, 1, 2, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, position, updatedPosition)
+	assertBetween(t, "K", position, "V")
+
+	var updates int
+	for _, query := range fake.Queries() {
+		if strings.HasPrefix(query, "UPDATE") {
+			updates++
+		}
+	}
+	assert.Equal(t, 1, updates, "only the moved todo is written")
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetProjectsByUser(userID int64, includeArchived bool) ([]entity.Project, error) {
+	var projects []entity.Project
+
+	query := t.DB.Scopes(t.ownerScope(userID))
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	result := query.Order("sort_order ASC, id ASC").Find(&projects)
+	return projects, result.Error
+}
+
+func (t TodoRepository) GetProjectByID(projectID, userID int64) (*entity.Project, error) {
+	var project entity.Project
+	result := t.DB.Scopes(t.ownerScope(userID)).Where("id = ?", projectID).First(&project)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &project, result.Error
+}
+
+func (t TodoRepository) CreateProject(project *entity.Project) error {
+	if project.WorkspaceID == nil {
+		project.WorkspaceID = t.WorkspaceID
+	}
+	return t.DB.Create(project).Error
+}
+
+func (t TodoRepository) UpdateProject(projectID, userID int64, updates map[string]interface{}) error {
+	return t.DB.Model(&entity.Project{}).Scopes(t.ownerScope(userID)).Where("id = ?", projectID).Updates(updates).Error
+}
+
+// DeleteProject menghapus project, todo di dalamnya tetap ada tanpa project (ON DELETE SET NULL).
+func (t TodoRepository) DeleteProject(projectID, userID int64) (int64, error) {
+	result := t.DB.Scopes(t.ownerScope(userID)).Where("id = ?", projectID).Delete(&entity.Project{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,63 @@
+package database
+
+import "strings"
+
+// rankAlphabet urut sesuai ASCII supaya urutan string sama dengan urutan rank
+// (kolom position memakai collation biner).
+const rankAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// maxRankLength adalah batas panjang rank sebelum posisi milik user di-rebalance.
+const maxRankLength = 200
+
+// rankBetween menghasilkan rank di antara prev dan next secara leksikografis.
+// String kosong berarti tidak ada batas di sisi tersebut.
+// Rank yang dihasilkan tidak pernah berakhir dengan '0', sehingga selalu ada
+// ruang untuk menyisipkan rank baru sebelum rank mana pun.
+func rankBetween(prev, next string) string {
+	var rank []byte
+	for i := 0; ; i++ {
+		low := 0
+		if i < len(prev) {
+			low = strings.IndexByte(rankAlphabet, prev[i])
+		}
+		high := len(rankAlphabet)
+		if i < len(next) {
+			high = strings.IndexByte(rankAlphabet, next[i])
+		}
+
+		if low == high {
+			rank = append(rank, rankAlphabet[low])
+			continue
+		}
+
+		mid := (low + high) / 2
+		if mid > low {
+			return string(append(rank, rankAlphabet[mid]))
+		}
+
+		// low dan high bersebelahan: ambil low, sisa digit bebas tanpa batas atas
+		rank = append(rank, rankAlphabet[low])
+		next = ""
+	}
+}
+
+// evenRanks menghasilkan n rank berjarak rata dengan panjang yang sama, dipakai saat rebalance.
+func evenRanks(n int) []string {
+	width := 1
+	for capacity := len(rankAlphabet); capacity <= n; capacity *= len(rankAlphabet) {
+		width++
+	}
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := i + 1
+		digits := make([]byte, width)
+		for d := width - 1; d >= 0; d-- {
+			digits[d] = rankAlphabet[value%len(rankAlphabet)]
+			value /= len(rankAlphabet)
+		}
+		// akhiran 'V' menjaga rank tidak berakhir dengan '0'
+		ranks[i] = string(digits) + "V"
+	}
+	return ranks
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// assertBetween memastikan rank berada di antara prev dan next dan masih bisa disisipi.
+func assertBetween(t *testing.T, prev, rank, next string) {
+	t.Helper()
+	if prev != "" {
+		require.Less(t, prev, rank)
+	}
+	if next != "" {
+		require.Less(t, rank, next)
+	}
+	require.False(t, strings.HasSuffix(rank, "0"), "rank %q ends with '0'", rank)
+}
+
+func TestRankBetween(t *testing.T) {
+	assert.Equal(t, "V", rankBetween("", ""), "first todo takes the middle of the alphabet")
+	assert.Equal(t, "AV", rankBetween("A", "B"), "adjacent ranks get a longer rank")
+	assert.Equal(t, "0V", rankBetween("", "1"))
+
+	t.Run("RepeatedlyAtTop", func(t *testing.T) {
+		first := rankBetween("", "")
+		for i := 0; i < 100; i++ {
+			rank := rankBetween("", first)
+			assertBetween(t, "", rank, first)
+			first = rank
+		}
+	})
+
+	t.Run("RepeatedlyAtBottom", func(t *testing.T) {
+		last := rankBetween("", "")
+		for i := 0; i < 100; i++ {
+			rank := rankBetween(last, "")
+			assertBetween(t, last, rank, "")
+			last = rank
+		}
+		// menambah di akhir list tidak membuat rank memanjang dengan cepat
+		assert.LessOrEqual(t, len(last), 20)
+	})
+
+	t.Run("RepeatedlyIntoSameGap", func(t *testing.T) {
+		prev, next := "A", "B"
+		for i := 0; i < 100; i++ {
+			rank := rankBetween(prev, next)
+			assertBetween(t, prev, rank, next)
+			next = rank
+		}
+		assert.Less(t, len(next), maxRankLength)
+	})
+}
+
+func TestEvenRanks(t *testing.T) {
+	for _, n := range []int{1, len(rankAlphabet) - 1, len(rankAlphabet), 5000} {
+		ranks := evenRanks(n)
+		require.Len(t, ranks, n)
+		assert.True(t, sort.StringsAreSorted(ranks), "n=%d", n)
+
+		// semua rank sama panjang dan masih menyisakan ruang di antaranya
+		for i := 1; i < n; i++ {
+			require.Len(t, ranks[i], len(ranks[0]))
+			assertBetween(t, ranks[i-1], rankBetween(ranks[i-1], ranks[i]), ranks[i])
+		}
+	}
+}
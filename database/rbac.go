@@ -0,0 +1,168 @@
+package database
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// HasPermission mengecek apakah salah satu role user punya permission tersebut.
+func (t TodoRepository) HasPermission(userID int64, permission string) (bool, error) {
+	var count int64
+	err := t.DB.Table("user_roles ur").
+		Joins("JOIN role_permissions rp ON rp.role_id = ur.role_id").
+		Joins("JOIN permissions p ON p.id = rp.permission_id").
+		Where("ur.user_id = ? AND p.name = ?", userID, permission).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetRolesByUsers mengambil nama role untuk beberapa user sekaligus.
+func (t TodoRepository) GetRolesByUsers(userIDs []int64) (map[int64][]string, error) {
+	roles := make(map[int64][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return roles, nil
+	}
+
+	var rows []struct {
+		UserID int64
+		Name   string
+	}
+	err := t.DB.Table("user_roles ur").
+		Select("ur.user_id, r.name").
+		Joins("JOIN roles r ON r.id = ur.role_id").
+		Where("ur.user_id IN ?", userIDs).
+		Order("r.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		roles[row.UserID] = append(roles[row.UserID], row.Name)
+	}
+	return roles, nil
+}
+
+// AssignRole memberi role ke user, mengembalikan false kalau role tidak dikenal.
+func (t TodoRepository) AssignRole(userID int64, roleName string) (bool, error) {
+	var role entity.Role
+	err := t.DB.Where("name = ?", roleName).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = t.DB.Exec("INSERT IGNORE INTO user_roles (user_id, role_id) VALUES (?, ?)", userID, role.ID).Error
+	return err == nil, err
+}
+
+// RemoveRole mencabut role dari user. Role admin terakhir tidak bisa dicabut,
+// dicek di transaksi yang sama supaya dua request paralel tidak menghapus semua admin.
+func (t TodoRepository) RemoveRole(userID int64, roleName string) (int64, error) {
+	var removed int64
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE ur FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE ur.user_id = ? AND r.name = ?",
+			userID, roleName)
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected
+		if removed == 0 || roleName != entity.RoleAdmin {
+			return nil
+		}
+
+		var remaining int64
+		err := tx.Raw("SELECT COUNT(*) FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE r.name = ? FOR UPDATE",
+			entity.RoleAdmin).Scan(&remaining).Error
+		if err != nil {
+			return err
+		}
+		if remaining == 0 {
+			return repository.ErrLastAdmin
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// GetUsers menampilkan user per halaman, query mencari username yang mengandung teks tersebut.
+func (t TodoRepository) GetUsers(query string, page, perPage int) ([]entity.User, int64, error) {
+	var users []entity.User
+
+	db := t.DB.Model(&entity.User{})
+	if query != "" {
+		db = db.Where("username LIKE ?", "%"+query+"%")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	err := db.Order("id ASC").Offset(offset).Limit(perPage).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// SetUserDisabled menonaktifkan atau mengaktifkan kembali akun user.
+func (t TodoRepository) SetUserDisabled(userID int64, disabled bool) (int64, error) {
+	var disabledAt *time.Time
+	if disabled {
+		now := time.Now()
+		disabledAt = &now
+	}
+	result := t.DB.Model(&entity.User{}).Where("id = ?", userID).Update("disabled_at", disabledAt)
+	return result.RowsAffected, result.Error
+}
+
+// UpdatePassword menyimpan hash password baru, resetRequired memaksa user menggantinya lagi.
+func (t TodoRepository) UpdatePassword(userID int64, hashedPassword string, resetRequired bool) (int64, error) {
+	result := t.DB.Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"password":                hashedPassword,
+		"password_reset_required": resetRequired,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteUser menghapus user beserta file lampiran todo miliknya. Baris todo, workspace yang
+// dimiliki user (dan todo di dalamnya) ikut terhapus lewat ON DELETE CASCADE. Baris dihapus
+// dulu di dalam transaksi, baru file lampirannya; file yang gagal dihapus hanya dicatat di log.
+func (t TodoRepository) DeleteUser(userID int64) (int64, error) {
+	var todos []entity.Todolist
+	var deleted int64
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Preload("Attachments").
+			Where("user_id = ? OR workspace_id IN (?)", userID,
+				tx.Table("workspaces").Select("id").Where("owner_id = ?", userID)).
+			Find(&todos).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.User{}, userID)
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil || deleted == 0 {
+		return deleted, err
+	}
+
+	for i := range todos {
+		if err := t.removeAttachmentFiles(todos[i].Attachments); err != nil {
+			logrus.Errorf("failed when removing attachment files of todo %d: %v", todos[i].ID, err)
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// userDB menyiapkan user 3 dengan satu todo berlampiran di path. deleteErr dipakai
+// untuk mensimulasikan DELETE user yang gagal.
+func userDB(t *testing.T, path string, deleteErr error) TodoRepository {
+	db, _ := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "SELECT * FROM `todolists`"):
+			return fakeResult{columns: []string{"id", "user_id"}, rows: [][]driver.Value{{int64(7), int64(3)}}}
+		case strings.HasPrefix(query, "SELECT * FROM `attachments`"):
+			return fakeResult{columns: []string{"id", "todo_id", "path"}, rows: [][]driver.Value{{int64(1), int64(7), path}}}
+		case strings.HasPrefix(query, "DELETE FROM `users`"):
+			return fakeResult{affected: 1, err: deleteErr}
+		}
+		return fakeResult{}
+	})
+	return TodoRepository{DB: db}
+}
+
+func TestDeleteUserRemovesFilesAfterCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foto.png")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+
+	deleted, err := userDB(t, path, nil).DeleteUser(3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.NoFileExists(t, path)
+}
+
+func TestDeleteUserKeepsFilesWhenDeleteFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foto.png")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+
+	// user masih ada, jadi file lampirannya juga tidak boleh hilang
+	_, err := userDB(t, path, errors.New("lock wait timeout")).DeleteUser(3)
+	assert.Error(t, err)
+	assert.FileExists(t, path)
+}
+
+// roleDB menjawab DELETE user_roles dengan removed baris dan COUNT admin dengan remaining.
+func roleDB(t *testing.T, removed, remaining int64) (*fakeDB, TodoRepository) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return fakeResult{columns: []string{"count"}, rows: [][]driver.Value{{remaining}}}
+		}
+		return fakeResult{affected: removed}
+	})
+	return fake, TodoRepository{DB: db}
+}
+
+func TestRemoveLastAdminRole(t *testing.T) {
+	fake, repo := roleDB(t, 1, 0)
+
+	_, err := repo.RemoveRole(2, entity.RoleAdmin)
+	assert.ErrorIs(t, err, repository.ErrLastAdmin)
+
+	queries := fake.Queries()
+	assert.Contains(t, queries[2], "FOR UPDATE")
+	assert.Equal(t, "ROLLBACK", queries[len(queries)-1], "the delete is undone")
+}
+
+func TestRemoveRoleKeepsOtherAdmins(t *testing.T) {
+	_, repo := roleDB(t, 1, 2)
+	removed, err := repo.RemoveRole(2, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	// role selain admin tidak perlu dihitung
+	fake, repo := roleDB(t, 1, 0)
+	_, err = repo.RemoveRole(2, "editor")
+	require.NoError(t, err)
+	assert.Len(t, fake.Queries(), 3)
+}
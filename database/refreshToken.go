@@ -0,0 +1,48 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) CreateRefreshToken(token *entity.RefreshToken) error {
+	return t.DB.Create(token).Error
+}
+
+func (t TodoRepository) GetRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	result := t.DB.Where("token_hash = ?", tokenHash).First(&token)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &token, result.Error
+}
+
+// RotateRefreshToken mencabut token lama dan menyimpan penggantinya dalam satu transaksi.
+// Mengembalikan false kalau token lama ternyata sudah dicabut (dipakai dua kali bersamaan).
+func (t TodoRepository) RotateRefreshToken(oldID int64, next *entity.RefreshToken) (bool, error) {
+	rotated := false
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", oldID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		rotated = true
+		return tx.Create(next).Error
+	})
+	return rotated, err
+}
+
+// DeleteExpiredRefreshTokens menghapus refresh token yang sudah kadaluwarsa sebelum "before".
+func (t TodoRepository) DeleteExpiredRefreshTokens(before time.Time) (int64, error) {
+	result := t.DB.Where("expires_at < ?", before).Delete(&entity.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+// RevokeToken memasukkan jti access token ke denylist.
+func (t TodoRepository) RevokeToken(token *entity.RevokedToken) error {
+	return t.DB.Exec("INSERT IGNORE INTO revoked_tokens (jti, user_id, expires_at) VALUES (?, ?, ?)",
+		token.JTI, token.UserID, token.ExpiresAt).Error
+}
+
+func (t TodoRepository) GetRevokedToken(jti string) (*entity.RevokedToken, error) {
+	var token entity.RevokedToken
+	result := t.DB.Where("jti = ?", jti).First(&token)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &token, result.Error
+}
+
+// DeleteExpiredRevokedTokens menghapus isi denylist yang token-nya sudah kadaluwarsa,
+// token tersebut sudah ditolak oleh pengecekan exp.
+func (t TodoRepository) DeleteExpiredRevokedTokens(before time.Time) (int64, error) {
+	result := t.DB.Where("expires_at < ?", before).Delete(&entity.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
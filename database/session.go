@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) CreateSession(session *entity.Session) error {
+	return t.DB.Create(session).Error
+}
+
+// TouchSession memperbarui last_seen_at, mengembalikan false kalau sesi tidak ada atau sudah dicabut.
+func (t TodoRepository) TouchSession(sessionID string, now time.Time) (bool, error) {
+	var session entity.Session
+	result := t.DB.Where("id = ? AND revoked_at IS NULL", sessionID).First(&session)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	err := t.DB.Model(&entity.Session{}).Where("id = ?", sessionID).UpdateColumn("last_seen_at", now).Error
+	return err == nil, err
+}
+
+func (t TodoRepository) GetActiveSessions(userID int64) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := t.DB.Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession mencabut sesi milik user beserta refresh token di family-nya.
+func (t TodoRepository) RevokeSession(sessionID string, userID int64) (int64, error) {
+	return t.revokeSessions("id = ? AND user_id = ?", sessionID, userID)
+}
+
+// RevokeUserSessions mencabut semua sesi user kecuali exceptSessionID (kosong berarti semua).
+func (t TodoRepository) RevokeUserSessions(userID int64, exceptSessionID string) (int64, error) {
+	return t.revokeSessions("user_id = ? AND id <> ?", userID, exceptSessionID)
+}
+
+func (t TodoRepository) revokeSessions(query string, args ...interface{}) (int64, error) {
+	var revoked int64
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		err := tx.Model(&entity.Session{}).Where(query, args...).Where("revoked_at IS NULL").Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&entity.Session{}).Where("id IN ?", ids).Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		revoked = result.RowsAffected
+
+		return tx.Model(&entity.RefreshToken{}).
+			Where("family_id IN ? AND revoked_at IS NULL", ids).
+			Update("revoked_at", now).Error
+	})
+	return revoked, err
+}
+
+// DeleteStaleSessions menghapus sesi yang sudah lama tidak dipakai atau sudah lama dicabut.
+func (t TodoRepository) DeleteStaleSessions(idleBefore, revokedBefore time.Time) (int64, error) {
+	result := t.DB.Where("last_seen_at < ? OR revoked_at < ?", idleBefore, revokedBefore).
+		Delete(&entity.Session{})
+	return result.RowsAffected, result.Error
+}
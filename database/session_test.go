@@ -0,0 +1,51 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestRevokeUserSessionsAlsoRevokesRefreshTokens(t *testing.T) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "SELECT `id` FROM `sessions`"):
+			return fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{"sesi-a"}, {"sesi-b"}}}
+		case strings.HasPrefix(query, "UPDATE `sessions`"):
+			return fakeResult{affected: 2}
+		}
+		return fakeResult{affected: 1}
+	})
+	repo := TodoRepository{DB: db}
+
+	revoked, err := repo.RevokeUserSessions(7, "sesi-sekarang")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+
+	queries := fake.Queries()
+	require.Len(t, queries, 5)
+	assert.Equal(t, "BEGIN", queries[0])
+	assert.Contains(t, queries[1], "user_id = ? AND id <> ?")
+	assert.Contains(t, queries[2], "UPDATE `sessions` SET `revoked_at`")
+	// refresh token dari sesi yang sama ikut dicabut di transaksi yang sama
+	assert.Contains(t, queries[3], "UPDATE `refresh_tokens` SET `revoked_at`")
+	assert.Contains(t, queries[3], "family_id IN (?,?)")
+	assert.Equal(t, "COMMIT", queries[4])
+}
+
+func TestRevokeSessionNothingToRevoke(t *testing.T) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		return fakeResult{columns: []string{"id"}}
+	})
+	repo := TodoRepository{DB: db}
+
+	revoked, err := repo.RevokeSession("sesi-lain", 7)
+	require.NoError(t, err)
+	assert.Zero(t, revoked)
+
+	for _, query := range fake.Queries() {
+		assert.False(t, strings.HasPrefix(query, "UPDATE"), query)
+	}
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"todoGin/model/entity"
+)
+
+// ShareTodo membagikan todo ke user lain, kalau sudah pernah dibagikan role-nya diganti.
+func (t TodoRepository) ShareTodo(share *entity.TodoShare) error {
+	return t.DB.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(share).Error
+}
+
+func (t TodoRepository) GetTodoShares(todoID int64) ([]entity.TodoShare, error) {
+	var shares []entity.TodoShare
+	err := t.DB.Table("todo_shares s").
+		Select("s.*, u.username").
+		Joins("JOIN users u ON u.id = s.user_id").
+		Where("s.todo_id = ?", todoID).
+		Order("s.created_at ASC").
+		Find(&shares).Error
+	return shares, err
+}
+
+func (t TodoRepository) RemoveTodoShare(todoID, userID int64) (int64, error) {
+	result := t.DB.Where("todo_id = ? AND user_id = ?", todoID, userID).Delete(&entity.TodoShare{})
+	return result.RowsAffected, result.Error
+}
+
+// GetSharedWithUser mengambil todo milik user lain yang dibagikan ke userID, baik
+// langsung maupun lewat project yang dibagikan. Dengan workspace aktif hanya todo
+// di workspace itu.
+func (t TodoRepository) GetSharedWithUser(userID int64) ([]entity.Todolist, error) {
+	var shares []entity.TodoShare
+	if err := t.DB.Where("user_id = ?", userID).Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	var projectShares []entity.ProjectShare
+	if err := t.DB.Where("user_id = ?", userID).Find(&projectShares).Error; err != nil {
+		return nil, err
+	}
+	if len(shares) == 0 && len(projectShares) == 0 {
+		return []entity.Todolist{}, nil
+	}
+
+	roles := make(map[int64]string, len(shares))
+	ids := make([]int64, len(shares))
+	for i, share := range shares {
+		roles[share.TodoID] = share.Role
+		ids[i] = share.TodoID
+	}
+	projectRoles := make(map[int64]string, len(projectShares))
+	projectIDs := make([]int64, len(projectShares))
+	for i, share := range projectShares {
+		projectRoles[share.ProjectID] = share.Role
+		projectIDs[i] = share.ProjectID
+	}
+
+	var todos []entity.Todolist
+	err := t.DB.Preload("Attachments").Preload("Labels").
+		Scopes(t.workspaceScope).
+		Where("(id IN ? OR project_id IN ?) AND user_id <> ? AND archived_at IS NULL", ids, projectIDs, userID).
+		Order("id DESC").
+		Find(&todos).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range todos {
+		role := roles[todos[i].ID]
+		if todos[i].ProjectID != nil {
+			role = strongerShareRole(role, projectRoles[*todos[i].ProjectID])
+		}
+		todos[i].AccessRole = role
+	}
+
+	return todos, t.decorate(todos)
+}
+
+// ShareProject membagikan project ke user lain, kalau sudah pernah dibagikan role-nya diganti.
+func (t TodoRepository) ShareProject(share *entity.ProjectShare) error {
+	return t.DB.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(share).Error
+}
+
+func (t TodoRepository) GetProjectShares(projectID int64) ([]entity.ProjectShare, error) {
+	var shares []entity.ProjectShare
+	err := t.DB.Table("project_shares s").
+		Select("s.*, u.username").
+		Joins("JOIN users u ON u.id = s.user_id").
+		Where("s.project_id = ?", projectID).
+		Order("s.created_at ASC").
+		Find(&shares).Error
+	return shares, err
+}
+
+func (t TodoRepository) RemoveProjectShare(projectID, userID int64) (int64, error) {
+	result := t.DB.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&entity.ProjectShare{})
+	return result.RowsAffected, result.Error
+}
+
+// GetProjectsSharedWithUser mengambil project milik user lain yang dibagikan ke userID.
+func (t TodoRepository) GetProjectsSharedWithUser(userID int64) ([]entity.Project, error) {
+	var shares []entity.ProjectShare
+	if err := t.DB.Where("user_id = ?", userID).Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	if len(shares) == 0 {
+		return []entity.Project{}, nil
+	}
+
+	roles := make(map[int64]string, len(shares))
+	ids := make([]int64, len(shares))
+	for i, share := range shares {
+		roles[share.ProjectID] = share.Role
+		ids[i] = share.ProjectID
+	}
+
+	var projects []entity.Project
+	err := t.DB.Scopes(t.workspaceScope).
+		Where("id IN ? AND archived = ?", ids, false).
+		Order("sort_order ASC, id ASC").
+		Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		projects[i].AccessRole = roles[projects[i].ID]
+	}
+	return projects, nil
+}
+
+// accessRole menentukan hak akses userID terhadap todo: owner, assignee, editor,
+// viewer atau kosong kalau tidak punya akses sama sekali. Share todo dan share
+// project digabung, role yang lebih tinggi yang dipakai.
+func (t TodoRepository) accessRole(todo *entity.Todolist, userID int64) (string, error) {
+	if t.WorkspaceID != nil && todo.WorkspaceID != nil && *todo.WorkspaceID == *t.WorkspaceID {
+		return t.workspaceAccess(todo, userID), nil
+	}
+	if todo.UserID == userID && todo.WorkspaceID == nil {
+		return entity.AccessOwner, nil
+	}
+	if todo.AssigneeID != nil && *todo.AssigneeID == userID {
+		return entity.AccessAssignee, nil
+	}
+
+	var share entity.TodoShare
+	err := t.DB.Where("todo_id = ? AND user_id = ?", todo.ID, userID).First(&share).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if todo.ProjectID == nil || share.Role == entity.ShareRoleEditor {
+		return share.Role, nil
+	}
+
+	var projectShare entity.ProjectShare
+	err = t.DB.Where("project_id = ? AND user_id = ?", *todo.ProjectID, userID).First(&projectShare).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	return strongerShareRole(share.Role, projectShare.Role), nil
+}
+
+// strongerShareRole memilih role share yang lebih tinggi, editor di atas viewer.
+func strongerShareRole(a, b string) string {
+	if a == entity.ShareRoleEditor || b == "" {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) CreateShareLink(link *entity.ShareLink) error {
+	return t.DB.Create(link).Error
+}
+
+func (t TodoRepository) GetShareLink(linkID int64) (*entity.ShareLink, error) {
+	var link entity.ShareLink
+	result := t.DB.Where("id = ?", linkID).First(&link)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &link, result.Error
+}
+
+// GetActiveShareLinks mengambil share link milik user yang belum dicabut dan belum kadaluwarsa.
+func (t TodoRepository) GetActiveShareLinks(userID int64, now time.Time) ([]entity.ShareLink, error) {
+	var links []entity.ShareLink
+	err := t.DB.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("created_at DESC, id DESC").
+		Find(&links).Error
+	return links, err
+}
+
+func (t TodoRepository) RevokeShareLink(linkID, userID int64) (int64, error) {
+	result := t.DB.Model(&entity.ShareLink{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", linkID, userID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// GetPublicTodo mengambil todo untuk share link tanpa filter user.
+func (t TodoRepository) GetPublicTodo(todoID int64) (*entity.Todolist, error) {
+	var todo entity.Todolist
+	result := t.DB.Preload("Attachments").
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_order ASC, id ASC")
+		}).
+		Where("id = ?", todoID).First(&todo)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	todos := []entity.Todolist{todo}
+	if err := t.fillProgress(todos); err != nil {
+		return nil, err
+	}
+	return &todos[0], nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+)
+
+func TestAccessRole(t *testing.T) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		if strings.HasPrefix(query, "SELECT * FROM `todo_shares`") && args[1].Value == int64(3) {
+			return fakeResult{columns: []string{"todo_id", "user_id", "role"}, rows: [][]driver.Value{{int64(1), int64(3), entity.ShareRoleEditor}}}
+		}
+		return fakeResult{columns: []string{"todo_id", "user_id", "role"}}
+	})
+	repo := TodoRepository{DB: db}
+	todo := &entity.Todolist{ID: 1, UserID: 2}
+
+	role, err := repo.accessRole(todo, 2)
+	require.NoError(t, err)
+	assert.Equal(t, entity.AccessOwner, role)
+	assert.Empty(t, fake.Queries(), "owner does not need a share lookup")
+
+	role, err = repo.accessRole(todo, 3)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ShareRoleEditor, role)
+
+	// assignee didahulukan walaupun todo juga dibagikan ke user yang sama
+	assignee := int64(3)
+	todo.AssigneeID = &assignee
+	queries := len(fake.Queries())
+	role, err = repo.accessRole(todo, 3)
+	require.NoError(t, err)
+	assert.Equal(t, entity.AccessAssignee, role)
+	assert.Len(t, fake.Queries(), queries)
+
+	// tidak dibagikan: tanpa akses, bukan error
+	role, err = repo.accessRole(todo, 4)
+	require.NoError(t, err)
+	assert.Empty(t, role)
+}
+
+func TestUpdatetoAtchWritesOnlyTheAttachment(t *testing.T) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		return fakeResult{affected: 1}
+	})
+	repo := TodoRepository{DB: db}
+
+	// editor meng-upload lampiran ke todo milik owner, isi todo tidak boleh ikut ditulis ulang
+	require.NoError(t, repo.UpdatetoAtch(&entity.Attachment{ID: 4, TodoID: 1, Path: "uploads/foto.png", AttachmentOrder: 2}))
+
+	for _, query := range fake.Queries() {
+		assert.NotContains(t, query, "`todolists`")
+	}
+	assert.Contains(t, strings.Join(fake.Queries(), "\n"), "UPDATE `attachments`")
+}
+
+func TestAccessRoleThroughProjectShare(t *testing.T) {
+	// user 3 viewer di todo tapi editor di project, user 4 hanya viewer lewat project
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "SELECT * FROM `todo_shares`") && args[1].Value == int64(3):
+			return fakeResult{columns: []string{"todo_id", "user_id", "role"}, rows: [][]driver.Value{{int64(1), int64(3), entity.ShareRoleViewer}}}
+		case strings.HasPrefix(query, "SELECT * FROM `todo_shares`") && args[1].Value == int64(5):
+			return fakeResult{columns: []string{"todo_id", "user_id", "role"}, rows: [][]driver.Value{{int64(1), int64(5), entity.ShareRoleEditor}}}
+		case strings.HasPrefix(query, "SELECT * FROM `project_shares`") && args[1].Value == int64(3):
+			return fakeResult{columns: []string{"project_id", "user_id", "role"}, rows: [][]driver.Value{{int64(7), int64(3), entity.ShareRoleEditor}}}
+		case strings.HasPrefix(query, "SELECT * FROM `project_shares`") && args[1].Value == int64(4):
+			return fakeResult{columns: []string{"project_id", "user_id", "role"}, rows: [][]driver.Value{{int64(7), int64(4), entity.ShareRoleViewer}}}
+		}
+		return fakeResult{columns: []string{"user_id", "role"}}
+	})
+	repo := TodoRepository{DB: db}
+	projectID := int64(7)
+	todo := &entity.Todolist{ID: 1, UserID: 2, ProjectID: &projectID}
+
+	role, err := repo.accessRole(todo, 3)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ShareRoleEditor, role)
+
+	role, err = repo.accessRole(todo, 4)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ShareRoleViewer, role)
+
+	// editor di todo sudah role tertinggi, project_shares tidak perlu dibaca
+	queries := len(fake.Queries())
+	role, err = repo.accessRole(todo, 5)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ShareRoleEditor, role)
+	assert.Len(t, fake.Queries(), queries+1)
+
+	role, err = repo.accessRole(todo, 6)
+	require.NoError(t, err)
+	assert.Empty(t, role)
+}
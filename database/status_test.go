@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+func statusDB(t *testing.T, statusMatches bool) (*fakeDB, TodoRepository) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "UPDATE `todolists` SET `archived_at`"):
+			if !statusMatches {
+				return fakeResult{}
+			}
+			return fakeResult{affected: 1}
+		case strings.HasPrefix(query, "SELECT COALESCE(MAX(position)"):
+			return fakeResult{columns: []string{"position"}, rows: [][]driver.Value{{"m"}}}
+		}
+		return fakeResult{affected: 1, insertID: 9}
+	})
+	return fake, TodoRepository{DB: db}
+}
+
+func TestChangeStatusCreatesNextOccurrence(t *testing.T) {
+	fake, repo := statusDB(t, true)
+	next := &entity.Todolist{Title: "Laporan harian", UserID: 1, Recurrence: "FREQ=DAILY"}
+
+	_, err := repo.ChangeStatus(1, entity.StatusInProgress, entity.StatusDone, nil, next)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusTodo, next.Status)
+
+	queries := fake.Queries()
+	require.NotEmpty(t, queries)
+	assert.Equal(t, "BEGIN", queries[0])
+	assert.Equal(t, "COMMIT", queries[len(queries)-1])
+	joined := strings.Join(queries, "\n")
+	assert.Contains(t, joined, "INSERT INTO `todolists`")
+	assert.Contains(t, joined, "INSERT INTO todolist_labels")
+	assert.Equal(t, 1, strings.Count(joined, "BEGIN"), "status change and next occurrence share one transaction")
+}
+
+func TestChangeStatusConflictSkipsNextOccurrence(t *testing.T) {
+	fake, repo := statusDB(t, false)
+
+	_, err := repo.ChangeStatus(1, entity.StatusInProgress, entity.StatusDone, nil, &entity.Todolist{Title: "Laporan harian", UserID: 1})
+	assert.ErrorIs(t, err, repository.ErrConflict)
+
+	queries := fake.Queries()
+	assert.Equal(t, "ROLLBACK", queries[len(queries)-1])
+	assert.NotContains(t, strings.Join(queries, "\n"), "INSERT INTO `todolists`")
+}
+
+func TestChangeStatusSavesFieldUpdatesTogether(t *testing.T) {
+	fake, repo := statusDB(t, true)
+
+	_, err := repo.ChangeStatus(1, entity.StatusTodo, entity.StatusInProgress, map[string]interface{}{"title": "Final"}, nil)
+	require.NoError(t, err)
+
+	// judul dan status ditulis di satu UPDATE yang sama-sama bergantung pada status lama
+	queries := fake.Queries()
+	require.Len(t, queries, 4)
+	assert.Contains(t, queries[1], "`title`=?")
+	assert.Contains(t, queries[1], "`status`=?")
+	assert.Contains(t, queries[1], "WHERE (id = ? AND status = ?)")
+	assert.Contains(t, queries[2], "INSERT INTO `todo_status_transitions`")
+}
@@ -15,12 +15,18 @@ import (
 	"path/filepath"
 	"time"
 	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/repository"
 )
 
 // adaptop pattern
 type TodoRepository struct {
 	DB       *gorm.DB
 	S3Bucket *s3.Client
+
+	// WorkspaceID dan WorkspaceRole diisi lewat InWorkspace, nil berarti data pribadi user
+	WorkspaceID   *int64
+	WorkspaceRole string
 }
 
 func NewTodoRepository(DB *gorm.DB, s3Bucket *s3.Client) *TodoRepository {
@@ -30,42 +36,208 @@ func NewTodoRepository(DB *gorm.DB, s3Bucket *s3.Client) *TodoRepository {
 	}
 }
 
-func (t TodoRepository) GetAll() ([]entity.Todolist, error) {
+// GetAll menampilkan todo dari semua akun per halaman, hanya dipakai route admin.
+func (t TodoRepository) GetAll(page, perPage int) ([]entity.Todolist, int64, error) {
 	var todos []entity.Todolist
 
-	result := t.DB.Preload("Attachments").Preload("User").Find(&todos)
-	return todos, result.Error
+	var total int64
+	if err := t.DB.Model(&entity.Todolist{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	result := t.DB.Preload("Attachments").Preload("Labels").Order("user_id ASC, position ASC, id ASC").
+		Offset(offset).Limit(perPage).Find(&todos)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	if err := t.fillOwners(todos); err != nil {
+		return nil, 0, err
+	}
+	return todos, total, t.decorate(todos)
 }
 
-func (t TodoRepository) GetAllUserByID(UserID int64) ([]entity.Todolist, error) {
+func (t TodoRepository) GetAllUserByID(UserID int64, filter request.TodoFilter) ([]entity.Todolist, error) {
 	var todos []entity.Todolist
 
 	// Ambil semua Todolist berdasarkan user_id
-	result := t.DB.Preload("Attachments").Where("user_id = ?", UserID).Find(&todos)
+	result := t.DB.Preload("Attachments").Preload("Labels").
+		Scopes(todoFilterScope(filter), t.ownerScope(UserID), todoSortScope(filter.Sort)).Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	if err := t.decorate(todos); err != nil {
+		return nil, err
+	}
 	return todos, nil
 }
 
+// GetByID mengambil todo milik user, todo yang dibagikan ke user (langsung atau
+// lewat project) atau todo yang di-assign ke user, hak aksesnya ada di AccessRole.
 func (t TodoRepository) GetByID(todoID, userID int64) (*entity.Todolist, error) {
 	var todo entity.Todolist
-	result := t.DB.Preload("Attachments").Where("id = ? AND user_id = ?", todoID, userID).First(&todo)
+	cond, args := ownerCond("", userID, t.WorkspaceID)
+	args = append([]interface{}{todoID}, append(args, userID, userID, userID)...)
+	result := t.DB.Preload("Attachments").Preload("Labels").
+		Preload("StatusTransitions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("transitioned_at ASC, id ASC")
+		}).
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("item_order ASC, id ASC")
+		}).
+		Where("id = ? AND (("+cond+") OR assignee_id = ? OR id IN (SELECT todo_id FROM todo_shares WHERE user_id = ?) OR project_id IN (SELECT project_id FROM project_shares WHERE user_id = ?))", args...).
+		First(&todo)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-	return &todo, result.Error
+	role, err := t.accessRole(&todo, userID)
+	if err != nil {
+		return nil, err
+	}
+	todo.AccessRole = role
+
+	todos := []entity.Todolist{todo}
+	if err := t.decorate(todos); err != nil {
+		return nil, err
+	}
+	return &todos[0], nil
 }
 
-func (t TodoRepository) Create(title string, userID int64) (*entity.Todolist, error) {
-	todo := entity.Todolist{
-		Title:  title,
-		UserID: userID,
+func (t TodoRepository) Create(todo *entity.Todolist) (*entity.Todolist, error) {
+	if todo.Status == "" {
+		todo.Status = entity.StatusTodo
 	}
-	result := t.DB.Create(&todo)
-	return &todo, result.Error
+	if todo.Priority == 0 {
+		todo.Priority = entity.PriorityLowest
+	}
+	now := time.Now()
+	todo.StatusChangedAt = &now
+
+	if todo.WorkspaceID == nil {
+		todo.WorkspaceID = t.WorkspaceID
+	}
+
+	// todo baru selalu diletakkan di urutan paling akhir
+	last, err := lastPosition(t.DB, ownerScope(todo.UserID, todo.WorkspaceID))
+	if err != nil {
+		return nil, err
+	}
+	todo.Position = rankBetween(last, "")
+
+	result := t.DB.Create(todo)
+	return todo, result.Error
+}
+
+// ChangeStatus memindahkan status todo dari "from" ke "to" dan mencatat transisinya.
+// Kalau status di database sudah bukan "from" lagi, dikembalikan repository.ErrConflict.
+// updates (boleh kosong) adalah perubahan field lain yang disimpan bersama status.
+// next (boleh nil) adalah instance berikutnya dari todo berulang yang baru selesai,
+// dibuat di transaksi yang sama supaya todo tidak tertutup tanpa penggantinya.
+func (t TodoRepository) ChangeStatus(todoID int64, from, to string, updates map[string]interface{}, next *entity.Todolist) (*entity.StatusTransition, error) {
+	transition := &entity.StatusTransition{
+		TodoID:         todoID,
+		FromStatus:     from,
+		ToStatus:       to,
+		TransitionedAt: time.Now(),
+	}
+
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		values := map[string]interface{}{
+			"status":            to,
+			"status_changed_at": transition.TransitionedAt,
+			// todo yang statusnya berubah dikeluarkan dari arsip
+			"archived_at": nil,
+		}
+		for column, value := range updates {
+			values[column] = value
+		}
+
+		result := tx.Model(&entity.Todolist{}).
+			Where("id = ? AND status = ?", todoID, from).
+			Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrConflict
+		}
+
+		if err := tx.Create(transition).Error; err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		return createNextOccurrence(tx, todoID, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transition, nil
+}
+
+// createNextOccurrence membuat instance berikutnya dari todo berulang yang sudah selesai.
+// Label ikut disalin dan recurrence dipindah dari todo lama ke instance baru,
+// supaya membuka ulang todo lama tidak membuat instance ganda.
+func createNextOccurrence(tx *gorm.DB, doneID int64, next *entity.Todolist) error {
+	now := time.Now()
+	next.Status = entity.StatusTodo
+	next.StatusChangedAt = &now
+
+	last, err := lastPosition(tx, ownerScope(next.UserID, next.WorkspaceID))
+	if err != nil {
+		return err
+	}
+	next.Position = rankBetween(last, "")
+
+	if err := tx.Create(next).Error; err != nil {
+		return err
+	}
+
+	err = tx.Exec("INSERT INTO todolist_labels (todolist_id, label_id) SELECT ?, label_id FROM todolist_labels WHERE todolist_id = ?", next.ID, doneID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&entity.Todolist{}).Where("id = ?", doneID).Update("recurrence", "").Error
+}
+
+// GetDueBetween mengambil todo yang belum selesai dengan due_at dalam rentang [from, to).
+// from boleh nil untuk rentang tanpa batas bawah (dipakai untuk overdue).
+func (t TodoRepository) GetDueBetween(userID int64, from *time.Time, to time.Time) ([]entity.Todolist, error) {
+	var todos []entity.Todolist
+
+	query := t.DB.Preload("Attachments").Scopes(t.ownerScope(userID)).
+		Where("status NOT IN ? AND archived_at IS NULL AND due_at < ?", entity.ClosedStatuses, to)
+	if from != nil {
+		query = query.Where("due_at >= ?", *from)
+	}
+
+	result := query.Order("due_at ASC").Find(&todos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return todos, t.decorate(todos)
+}
+
+func (t TodoRepository) GetWithoutDueDate(userID int64) ([]entity.Todolist, error) {
+	var todos []entity.Todolist
+
+	result := t.DB.Preload("Attachments").Scopes(t.ownerScope(userID)).
+		Where("status NOT IN ? AND archived_at IS NULL AND due_at IS NULL", entity.ClosedStatuses).Find(&todos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return todos, t.decorate(todos)
 }
 
 func (t TodoRepository) Update(todoID, userID int64, updates map[string]interface{}) (*entity.Todolist, error) {
@@ -77,9 +249,10 @@ func (t TodoRepository) Update(todoID, userID int64, updates map[string]interfac
 	return &todo, result.Error
 }
 
-func (t TodoRepository) UpdatetoAtch(todo *entity.Todolist) error {
-	err := t.DB.Save(todo).Error
-	return err
+// UpdatetoAtch menyimpan lampiran todo. Hanya baris lampiran yang ditulis, bukan seluruh
+// todo, supaya perubahan todo dari request lain tidak tertimpa nilai lama.
+func (t TodoRepository) UpdatetoAtch(attachment *entity.Attachment) error {
+	return t.DB.Save(attachment).Error
 }
 
 func (t TodoRepository) Delete(todoID, userID int64) (int64, error) {
@@ -289,20 +462,85 @@ func (t *TodoRepository) UploadTodoFileLocalAtch(file *multipart.FileHeader, tod
 	return attachment, nil
 }
 
-func (t *TodoRepository) SearchTodolistByUser(userID int64, search string, page, perPage int) ([]entity.Todolist, int64, error) {
+func (t *TodoRepository) SearchTodolistByUser(userID int64, search string, page, perPage int, filter request.TodoFilter) ([]entity.Todolist, int64, error) {
 	var todos []entity.Todolist
 
 	// Menghitung total data
 	var total int64
-	t.DB.Model(&entity.Todolist{}).Where("user_id = ? AND title LIKE ?", userID, "%"+search+"%").Count(&total)
+	t.DB.Model(&entity.Todolist{}).Scopes(todoFilterScope(filter), t.ownerScope(userID)).
+		Where("(title LIKE ? OR description LIKE ?)", "%"+search+"%", "%"+search+"%").Count(&total)
 
 	// Mengambil data dengan paginasi
 	offset := (page - 1) * perPage
-	err := t.DB.Where("user_id = ? AND title LIKE ?", userID, "%"+search+"%").
-		Offset(offset).Limit(perPage).
-		Preload("Attachments").Find(&todos).Error
+	err := t.DB.Scopes(todoFilterScope(filter), t.ownerScope(userID)).
+		Where("(title LIKE ? OR description LIKE ?)", "%"+search+"%", "%"+search+"%").
+		Scopes(todoSortScope(filter.Sort)).Offset(offset).Limit(perPage).
+		Preload("Attachments").Preload("Labels").Find(&todos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return todos, total, t.decorate(todos)
+}
+
+// nullableSortFields diurutkan dengan NULL di akhir, baik ascending maupun descending.
+var nullableSortFields = map[string]bool{"due_at": true, "start_at": true, "estimate_minutes": true}
+
+// todoSortScope menerapkan urutan dari parameter sort, lalu urutan manual (position)
+// sebagai penentu kalau nilainya sama. Nama kolom sudah divalidasi di service.
+func todoSortScope(sort []request.SortField) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, field := range sort {
+			column := "todolists." + field.Field
+			if nullableSortFields[field.Field] {
+				db = db.Order(column + " IS NULL")
+			}
+			if field.Desc {
+				db = db.Order(column + " DESC")
+			} else {
+				db = db.Order(column + " ASC")
+			}
+		}
+		return db.Order("todolists.position ASC, todolists.id ASC")
+	}
+}
+
+// decorate mengisi field turunan todo yang tidak disimpan di tabel todolists.
+func (t TodoRepository) decorate(todos []entity.Todolist) error {
+	if err := t.fillProgress(todos); err != nil {
+		return err
+	}
+	if err := t.fillBlocked(todos); err != nil {
+		return err
+	}
+	if err := t.fillCommentCount(todos); err != nil {
+		return err
+	}
+	return t.fillAssignees(todos)
+}
 
-	return todos, total, err
+// todoFilterScope menerapkan filter opsional ke query todolists.
+func todoFilterScope(filter request.TodoFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(filter.LabelIDs) > 0 {
+			labels := db.Session(&gorm.Session{NewDB: true}).
+				Table("todolist_labels").Select("todolist_id").
+				Where("label_id IN ?", filter.LabelIDs)
+			if filter.LabelMatch == request.LabelMatchAll {
+				// todo harus punya semua label yang diminta
+				labels = labels.Group("todolist_id").Having("COUNT(DISTINCT label_id) = ?", len(filter.LabelIDs))
+			}
+			db = db.Where("todolists.id IN (?)", labels)
+		}
+		if filter.ProjectID != nil {
+			db = db.Where("todolists.project_id = ?", *filter.ProjectID)
+		}
+		if !filter.IncludeArchived {
+			db = db.Where("todolists.archived_at IS NULL")
+		}
+
+		return db
+	}
 }
 
 ////////////////////////////////////////////////////////
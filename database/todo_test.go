@@ -0,0 +1,99 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+)
+
+// dryRunDB membuat koneksi gorm yang hanya menyusun SQL tanpa menghubungi MySQL.
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:pass@tcp(127.0.0.1:3306)/todo?parseTime=true",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+func TestTodoFilterScope(t *testing.T) {
+	db := dryRunDB(t)
+	query := func(filter request.TodoFilter) *gorm.Statement {
+		return db.Scopes(todoFilterScope(filter)).Find(&[]entity.Todolist{}).Statement
+	}
+
+	t.Run("NoLabels", func(t *testing.T) {
+		stmt := query(request.TodoFilter{LabelMatch: request.LabelMatchAny})
+		assert.NotContains(t, stmt.SQL.String(), "todolist_labels")
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		stmt := query(request.TodoFilter{LabelIDs: []int64{1, 2}, LabelMatch: request.LabelMatchAny})
+		assert.Contains(t, stmt.SQL.String(), "todolists.id IN (SELECT todolist_id FROM `todolist_labels` WHERE label_id IN (?,?))")
+		assert.NotContains(t, stmt.SQL.String(), "HAVING")
+		assert.Equal(t, []interface{}{int64(1), int64(2)}, stmt.Vars)
+	})
+
+	t.Run("All", func(t *testing.T) {
+		// todo harus punya kedua label, jadi jumlah label yang cocok per todo harus 2
+		stmt := query(request.TodoFilter{LabelIDs: []int64{1, 2}, LabelMatch: request.LabelMatchAll})
+		assert.Contains(t, stmt.SQL.String(), "GROUP BY `todolist_id` HAVING COUNT(DISTINCT label_id) = ?")
+		assert.Equal(t, []interface{}{int64(1), int64(2), 2}, stmt.Vars)
+	})
+
+	t.Run("Project", func(t *testing.T) {
+		projectID := int64(7)
+		stmt := query(request.TodoFilter{LabelMatch: request.LabelMatchAny, ProjectID: &projectID})
+		assert.Contains(t, stmt.SQL.String(), "todolists.project_id = ?")
+		assert.Equal(t, []interface{}{int64(7)}, stmt.Vars)
+	})
+
+	t.Run("Archived", func(t *testing.T) {
+		stmt := query(request.TodoFilter{LabelMatch: request.LabelMatchAny})
+		assert.Contains(t, stmt.SQL.String(), "todolists.archived_at IS NULL")
+
+		stmt = query(request.TodoFilter{LabelMatch: request.LabelMatchAny, IncludeArchived: true})
+		assert.NotContains(t, stmt.SQL.String(), "archived_at")
+	})
+}
+
+func TestTodoSortScope(t *testing.T) {
+	db := dryRunDB(t)
+	orderBy := func(sort ...request.SortField) string {
+		sql := db.Scopes(todoSortScope(sort)).Find(&[]entity.Todolist{}).Statement.SQL.String()
+		return sql[strings.Index(sql, "ORDER BY"):]
+	}
+
+	// tanpa sort tetap memakai urutan manual
+	assert.Equal(t, "ORDER BY todolists.position ASC, todolists.id ASC", orderBy())
+
+	assert.Equal(t,
+		"ORDER BY todolists.priority ASC,todolists.due_at IS NULL,todolists.due_at DESC,todolists.position ASC, todolists.id ASC",
+		orderBy(request.SortField{Field: "priority"}, request.SortField{Field: "due_at", Desc: true}),
+		"todos without due date stay at the end even when sorting descending")
+}
+
+func TestCreateDefaults(t *testing.T) {
+	db, _ := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		if strings.HasPrefix(query, "SELECT COALESCE(MAX(position)") {
+			return fakeResult{columns: []string{"position"}, rows: [][]driver.Value{{"V"}}}
+		}
+		return fakeResult{affected: 1, insertID: 10}
+	})
+	repo := TodoRepository{DB: db}
+
+	todo, err := repo.Create(&entity.Todolist{Title: "Tanpa prioritas", UserID: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), todo.ID)
+	assert.Equal(t, entity.PriorityLowest, todo.Priority)
+	assert.Equal(t, entity.StatusTodo, todo.Status)
+	// todo baru masuk di bawah todo terakhir
+	assert.Less(t, "V", todo.Position)
+}
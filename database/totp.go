@@ -0,0 +1,94 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+)
+
+// SetTOTPSecret menyimpan secret yang belum dikonfirmasi, 2FA belum aktif.
+func (t TodoRepository) SetTOTPSecret(userID int64, secret string) error {
+	return t.DB.Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"totp_secret":     secret,
+		"totp_enabled_at": nil,
+	}).Error
+}
+
+// EnableTOTP mengaktifkan 2FA dan mengganti semua recovery code dengan yang baru.
+func (t TodoRepository) EnableTOTP(userID int64, codeHashes []string) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.User{}).Where("id = ?", userID).Update("totp_enabled_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]entity.RecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = entity.RecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// DisableTOTP mematikan 2FA dan menghapus secret serta recovery code.
+func (t TodoRepository) DisableTOTP(userID int64) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"totp_secret":     nil,
+			"totp_enabled_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+	})
+}
+
+// UseRecoveryCode menandai recovery code sebagai terpakai, false kalau kode salah atau sudah dipakai.
+func (t TodoRepository) UseRecoveryCode(userID int64, codeHash string) (bool, error) {
+	result := t.DB.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (t TodoRepository) CountRecoveryCodes(userID int64) (int64, error) {
+	var count int64
+	err := t.DB.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// StartMFAAttempt memakai satu jatah percobaan kode 2FA sebelum kodenya dicek, jadi request paralel
+// tidak bisa melewati batas. Setelah maxAttempts percobaan tanpa berhasil, user dikunci sampai lockUntil.
+// Mengembalikan false kalau user sedang dikunci.
+func (t TodoRepository) StartMFAAttempt(userID int64, now time.Time, maxAttempts int, lockUntil time.Time) (bool, error) {
+	// mfa_locked_until di-set duluan karena MySQL memakai nilai kolom yang sudah diubah di assignment berikutnya
+	result := t.DB.Exec(`UPDATE users
+		SET mfa_locked_until = IF(mfa_failed_attempts + 1 >= ?, ?, NULL),
+			mfa_failed_attempts = IF(mfa_failed_attempts + 1 >= ?, 0, mfa_failed_attempts + 1)
+		WHERE id = ? AND (mfa_locked_until IS NULL OR mfa_locked_until <= ?)`,
+		maxAttempts, lockUntil, maxAttempts, userID, now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ResetMFAFailures dipanggil setelah kode 2FA benar.
+func (t TodoRepository) ResetMFAFailures(userID int64) error {
+	return t.DB.Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"mfa_failed_attempts": 0,
+		"mfa_locked_until":    nil,
+	}).Error
+}
+
+// AcceptTOTPStep menyimpan time step kode TOTP yang diterima. Kode untuk step yang sama
+// atau lebih lama ditolak supaya satu kode tidak bisa dipakai dua kali.
+func (t TodoRepository) AcceptTOTPStep(userID, step int64) (bool, error) {
+	result := t.DB.Model(&entity.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
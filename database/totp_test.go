@@ -0,0 +1,47 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartMFAAttemptWhileLocked(t *testing.T) {
+	// user yang masih dikunci tidak cocok dengan WHERE, jadi tidak ada baris yang berubah
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		return fakeResult{affected: 0}
+	})
+	repo := TodoRepository{DB: db}
+
+	now := time.Now()
+	allowed, err := repo.StartMFAAttempt(7, now, 5, now.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	queries := fake.Queries()
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "mfa_locked_until IS NULL OR mfa_locked_until <= ?")
+}
+
+func TestAcceptTOTPStepRejectsReplay(t *testing.T) {
+	var affected int64 = 1
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		return fakeResult{affected: affected}
+	})
+	repo := TodoRepository{DB: db}
+
+	accepted, err := repo.AcceptTOTPStep(7, 60000000)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	// step yang sama sudah tersimpan, UPDATE tidak mengubah apa pun
+	affected = 0
+	accepted, err = repo.AcceptTOTPStep(7, 60000000)
+	require.NoError(t, err)
+	assert.False(t, accepted)
+
+	assert.Contains(t, strings.Join(fake.Queries(), "\n"), "totp_last_step < ?")
+}
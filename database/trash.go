@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"os"
+	"strings"
+	"time"
+	"todoGin/model/entity"
+)
+
+func (t TodoRepository) GetTrashByUser(userID int64) ([]entity.Todolist, error) {
+	var todos []entity.Todolist
+	result := t.DB.Unscoped().Preload("Attachments").
+		Scopes(t.ownerScope(userID)).Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Find(&todos)
+	return todos, result.Error
+}
+
+func (t TodoRepository) RestoreTodo(todoID, userID int64) (int64, error) {
+	result := t.DB.Unscoped().Model(&entity.Todolist{}).Scopes(t.ownerScope(userID)).
+		Where("id = ? AND deleted_at IS NOT NULL", todoID).
+		Update("deleted_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// GetTrashedTodo mengambil todo di trash milik user (atau workspace aktif), nil kalau tidak ada.
+// Hak akses user terhadap todo ada di AccessRole.
+func (t TodoRepository) GetTrashedTodo(todoID, userID int64) (*entity.Todolist, error) {
+	var todo entity.Todolist
+	result := t.DB.Unscoped().Scopes(t.ownerScope(userID)).
+		Where("id = ? AND deleted_at IS NOT NULL", todoID).
+		First(&todo)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	role, err := t.accessRole(&todo, userID)
+	todo.AccessRole = role
+	return &todo, err
+}
+
+// PurgeTodo menghapus permanen satu todo yang ada di trash beserta file lampirannya.
+func (t TodoRepository) PurgeTodo(todoID, userID int64) (int64, error) {
+	var todos []entity.Todolist
+	err := t.DB.Unscoped().Preload("Attachments").Scopes(t.ownerScope(userID)).
+		Where("id = ? AND deleted_at IS NOT NULL", todoID).
+		Find(&todos).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(todos) == 0 {
+		return 0, nil
+	}
+	// kalau file gagal dihapus, error dikembalikan supaya user tahu purge-nya gagal
+	if err := t.removeAttachmentFiles(todos[0].Attachments); err != nil {
+		return 0, err
+	}
+	return t.deleteTodoRow(todos[0].ID)
+}
+
+// PurgeTrash menghapus permanen semua todo yang sudah di trash sebelum waktu "before".
+func (t TodoRepository) PurgeTrash(before time.Time) (int64, error) {
+	var todos []entity.Todolist
+	err := t.DB.Unscoped().Preload("Attachments").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Find(&todos).Error
+	if err != nil {
+		return 0, err
+	}
+	return t.purgeTodos(todos)
+}
+
+// purgeTodos dipakai purge terjadwal: file lampiran dihapus dulu, baru baris todo (lampiran
+// di database ikut terhapus lewat ON DELETE CASCADE). Todo yang filenya gagal dihapus
+// dilewati dan dicoba lagi di purge berikutnya.
+func (t TodoRepository) purgeTodos(todos []entity.Todolist) (int64, error) {
+	var purged int64
+	for i := range todos {
+		if err := t.removeAttachmentFiles(todos[i].Attachments); err != nil {
+			logrus.Errorf("failed when removing attachment files of todo %d: %v", todos[i].ID, err)
+			continue
+		}
+
+		deleted, err := t.deleteTodoRow(todos[i].ID)
+		if err != nil {
+			return purged, err
+		}
+		purged += deleted
+	}
+	return purged, nil
+}
+
+func (t TodoRepository) deleteTodoRow(todoID int64) (int64, error) {
+	result := t.DB.Unscoped().Delete(&entity.Todolist{}, todoID)
+	return result.RowsAffected, result.Error
+}
+
+// removeAttachmentFiles menghapus file lampiran dari S3 atau dari folder uploads lokal.
+func (t TodoRepository) removeAttachmentFiles(attachments []entity.Attachment) error {
+	for _, attachment := range attachments {
+		if bucket, key, ok := s3Location(attachment.Path); ok {
+			if t.S3Bucket == nil {
+				return errors.New("s3 client is not configured")
+			}
+			_, err := t.S3Bucket.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.Remove(attachment.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// s3Location membaca bucket dan key dari URL publik yang dibuat saat upload ke S3,
+// contoh https://bucketwithrey.s3.amazonaws.com/<key>.
+func s3Location(path string) (bucket, key string, ok bool) {
+	rest, found := strings.CutPrefix(path, "https://")
+	if !found {
+		return "", "", false
+	}
+	host, key, found := strings.Cut(rest, "/")
+	if !found {
+		return "", "", false
+	}
+	bucket, found = strings.CutSuffix(host, ".s3.amazonaws.com")
+	if !found {
+		return "", "", false
+	}
+	return bucket, key, true
+}
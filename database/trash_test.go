@@ -0,0 +1,110 @@
+package database
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// trashDB menyiapkan fakeDB dengan todo 7 di trash yang punya satu lampiran di path.
+func trashDB(t *testing.T, path string) (*fakeDB, TodoRepository) {
+	db, fake := newFakeDB(t, func(query string, args []driver.NamedValue) fakeResult {
+		switch {
+		case strings.HasPrefix(query, "SELECT * FROM `todolists`"):
+			return fakeResult{columns: []string{"id", "user_id"}, rows: [][]driver.Value{{int64(7), int64(1)}}}
+		case strings.HasPrefix(query, "SELECT * FROM `attachments`"):
+			return fakeResult{columns: []string{"id", "todo_id", "path"}, rows: [][]driver.Value{{int64(1), int64(7), path}}}
+		case strings.HasPrefix(query, "DELETE FROM `todolists`"):
+			return fakeResult{affected: 1}
+		}
+		return fakeResult{}
+	})
+	return fake, TodoRepository{DB: db}
+}
+
+func countDeletes(queries []string) int {
+	var deletes int
+	for _, query := range queries {
+		if strings.HasPrefix(query, "DELETE FROM `todolists`") {
+			deletes++
+		}
+	}
+	return deletes
+}
+
+func TestPurgeTrashRemovesAttachmentFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foto.png")
+	require.NoError(t, os.WriteFile(path, []byte("png"), 0o644))
+	fake, repo := trashDB(t, path)
+
+	purged, err := repo.PurgeTrash(time.Now().Add(-30 * 24 * time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), purged)
+	assert.NoFileExists(t, path)
+	assert.Equal(t, 1, countDeletes(fake.Queries()))
+	assert.Contains(t, fake.Queries()[0], "deleted_at IS NOT NULL AND deleted_at < ?")
+}
+
+func TestPurgeTodoWithMissingFile(t *testing.T) {
+	// file yang sudah tidak ada tidak boleh menahan purge
+	fake, repo := trashDB(t, filepath.Join(t.TempDir(), "hilang.png"))
+
+	purged, err := repo.PurgeTodo(7, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), purged)
+	assert.Equal(t, 1, countDeletes(fake.Queries()))
+}
+
+func TestPurgeTrashKeepsTodoWhenFileRemovalFails(t *testing.T) {
+	// direktori yang tidak kosong tidak bisa dihapus dengan os.Remove
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "isi.txt"), []byte("x"), 0o644))
+	fake, repo := trashDB(t, dir)
+
+	purged, err := repo.PurgeTrash(time.Now())
+	require.NoError(t, err)
+
+	assert.Zero(t, purged)
+	assert.Zero(t, countDeletes(fake.Queries()), "the todo stays in trash for the next purge")
+}
+
+func TestPurgeTodoReportsFileRemovalError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "isi.txt"), []byte("x"), 0o644))
+	fake, repo := trashDB(t, dir)
+
+	// purge manual memberi tahu user, bukan diam-diam melewati todo
+	purged, err := repo.PurgeTodo(7, 1)
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+	assert.Zero(t, countDeletes(fake.Queries()))
+}
+
+func TestPurgeTrashWithoutS3Client(t *testing.T) {
+	fake, repo := trashDB(t, "https://bucketwithrey.s3.amazonaws.com/uploads/foto.png")
+
+	purged, err := repo.PurgeTrash(time.Now())
+	require.NoError(t, err)
+
+	assert.Zero(t, purged)
+	assert.Zero(t, countDeletes(fake.Queries()))
+}
+
+func TestS3Location(t *testing.T) {
+	bucket, key, ok := s3Location("https://bucketwithrey.s3.amazonaws.com/uploads/foto.png")
+	assert.True(t, ok)
+	assert.Equal(t, "bucketwithrey", bucket)
+	assert.Equal(t, "uploads/foto.png", key)
+
+	for _, path := range []string{"uploads/foto.png", "https://example.com/foto.png", "https://bucketwithrey.s3.amazonaws.com"} {
+		_, _, ok := s3Location(path)
+		assert.False(t, ok, path)
+	}
+}
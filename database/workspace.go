@@ -0,0 +1,168 @@
+package database
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// InWorkspace mengembalikan salinan repository yang query todo dan project-nya
+// dibatasi ke satu workspace, role dipakai untuk menentukan hak akses todo.
+func (t TodoRepository) InWorkspace(workspaceID int64, role string) repository.TodoRepository {
+	scoped := t
+	scoped.WorkspaceID = &workspaceID
+	scoped.WorkspaceRole = role
+	return &scoped
+}
+
+// ownerCond adalah kondisi kepemilikan todolists/projects: tanpa workspace berarti
+// data pribadi user, dengan workspace berarti semua data di workspace itu.
+// prefix diisi alias tabel (misal "t.") kalau query memakai join.
+func ownerCond(prefix string, userID int64, workspaceID *int64) (string, []interface{}) {
+	if workspaceID != nil {
+		return prefix + "workspace_id = ?", []interface{}{*workspaceID}
+	}
+	return prefix + "user_id = ? AND " + prefix + "workspace_id IS NULL", []interface{}{userID}
+}
+
+func ownerScope(userID int64, workspaceID *int64) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		cond, args := ownerCond("", userID, workspaceID)
+		return db.Where(cond, args...)
+	}
+}
+
+func (t TodoRepository) ownerScope(userID int64) func(db *gorm.DB) *gorm.DB {
+	return ownerScope(userID, t.WorkspaceID)
+}
+
+// workspaceScope membatasi query todo ke workspace aktif untuk daftar yang tidak
+// berdasarkan kepemilikan (shared-with-me, assigned-to-me). Tanpa workspace tidak ada filter.
+func (t TodoRepository) workspaceScope(db *gorm.DB) *gorm.DB {
+	if t.WorkspaceID == nil {
+		return db
+	}
+	return db.Where("workspace_id = ?", *t.WorkspaceID)
+}
+
+// workspaceAccess memetakan role workspace ke hak akses todo di workspace tersebut.
+func (t TodoRepository) workspaceAccess(todo *entity.Todolist, userID int64) string {
+	switch {
+	case todo.UserID == userID, t.WorkspaceRole == entity.WorkspaceRoleOwner:
+		return entity.AccessOwner
+	case t.WorkspaceRole == entity.WorkspaceRoleAdmin:
+		return entity.AccessManager
+	case todo.AssigneeID != nil && *todo.AssigneeID == userID:
+		return entity.AccessAssignee
+	case t.WorkspaceRole == entity.WorkspaceRoleMember:
+		return entity.ShareRoleEditor
+	default:
+		return entity.ShareRoleViewer
+	}
+}
+
+// CreateWorkspace membuat workspace dan menjadikan pembuatnya owner.
+func (t TodoRepository) CreateWorkspace(workspace *entity.Workspace) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		workspace.Role = entity.WorkspaceRoleOwner
+		return tx.Create(&entity.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        entity.WorkspaceRoleOwner,
+		}).Error
+	})
+}
+
+func (t TodoRepository) GetWorkspacesByUser(userID int64) ([]entity.Workspace, error) {
+	var workspaces []entity.Workspace
+	err := t.DB.Table("workspaces w").
+		Select("w.*, m.role").
+		Joins("JOIN workspace_members m ON m.workspace_id = w.id").
+		Where("m.user_id = ?", userID).
+		Order("w.name ASC, w.id ASC").
+		Find(&workspaces).Error
+	return workspaces, err
+}
+
+func (t TodoRepository) GetWorkspaceMember(workspaceID, userID int64) (*entity.WorkspaceMember, error) {
+	var member entity.WorkspaceMember
+	result := t.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &member, result.Error
+}
+
+func (t TodoRepository) GetWorkspaceMembers(workspaceID int64) ([]entity.WorkspaceMember, error) {
+	var members []entity.WorkspaceMember
+	err := t.DB.Table("workspace_members m").
+		Select("m.*, u.username").
+		Joins("JOIN users u ON u.id = m.user_id").
+		Where("m.workspace_id = ?", workspaceID).
+		Order("m.created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (t TodoRepository) UpdateWorkspaceMemberRole(workspaceID, userID int64, role string) (int64, error) {
+	result := t.DB.Model(&entity.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role)
+	return result.RowsAffected, result.Error
+}
+
+func (t TodoRepository) RemoveWorkspaceMember(workspaceID, userID int64) (int64, error) {
+	result := t.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&entity.WorkspaceMember{})
+	return result.RowsAffected, result.Error
+}
+
+func (t TodoRepository) CreateWorkspaceInvitation(invitation *entity.WorkspaceInvitation) error {
+	return t.DB.Create(invitation).Error
+}
+
+func (t TodoRepository) GetPendingInvitations(userID int64) ([]entity.WorkspaceInvitation, error) {
+	var invitations []entity.WorkspaceInvitation
+	err := t.DB.Table("workspace_invitations i").
+		Select("i.*, w.name AS workspace_name").
+		Joins("JOIN workspaces w ON w.id = i.workspace_id").
+		Where("i.user_id = ? AND i.accepted_at IS NULL", userID).
+		Order("i.created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// AcceptWorkspaceInvitation menjadikan user anggota workspace sesuai role di undangan.
+// Mengembalikan nil kalau undangan tidak ada atau sudah pernah di-accept.
+func (t TodoRepository) AcceptWorkspaceInvitation(invitationID, userID int64) (*entity.WorkspaceMember, error) {
+	var member *entity.WorkspaceMember
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		var invitation entity.WorkspaceInvitation
+		err := tx.Where("id = ? AND user_id = ? AND accepted_at IS NULL", invitationID, userID).
+			First(&invitation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&invitation).Update("accepted_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		// user yang sudah jadi anggota tetap memakai role lamanya
+		member = &entity.WorkspaceMember{
+			WorkspaceID: invitation.WorkspaceID,
+			UserID:      userID,
+			Role:        invitation.Role,
+		}
+		return tx.Where("workspace_id = ? AND user_id = ?", invitation.WorkspaceID, userID).
+			FirstOrCreate(member).Error
+	})
+	return member, err
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"todoGin/model/entity"
+)
+
+func TestWorkspaceAccess(t *testing.T) {
+	assignee := int64(3)
+	todo := &entity.Todolist{ID: 1, UserID: 2, AssigneeID: &assignee}
+
+	cases := []struct {
+		role   string
+		userID int64
+		access string
+	}{
+		{entity.WorkspaceRoleOwner, 4, entity.AccessOwner},
+		// admin workspace bisa mengelola todo, tapi bukan pemiliknya
+		{entity.WorkspaceRoleAdmin, 4, entity.AccessManager},
+		{entity.WorkspaceRoleMember, 4, entity.ShareRoleEditor},
+		{entity.WorkspaceRoleGuest, 4, entity.ShareRoleViewer},
+		// pembuat todo dan assignee tetap punya hak masing-masing walaupun guest
+		{entity.WorkspaceRoleGuest, 2, entity.AccessOwner},
+		{entity.WorkspaceRoleGuest, 3, entity.AccessAssignee},
+	}
+	for _, c := range cases {
+		repo := TodoRepository{WorkspaceRole: c.role}
+		assert.Equal(t, c.access, repo.workspaceAccess(todo, c.userID), "%s user %d", c.role, c.userID)
+	}
+}
+
+func TestOwnerCond(t *testing.T) {
+	cond, args := ownerCond("t.", 1, nil)
+	assert.Equal(t, "t.user_id = ? AND t.workspace_id IS NULL", cond)
+	assert.Equal(t, []interface{}{int64(1)}, args)
+
+	workspaceID := int64(5)
+	cond, args = ownerCond("", 1, &workspaceID)
+	assert.Equal(t, "workspace_id = ?", cond)
+	assert.Equal(t, []interface{}{int64(5)}, args)
+}
+
+func TestWorkspaceScope(t *testing.T) {
+	db := dryRunDB(t)
+	query := func(repo TodoRepository) string {
+		return db.Scopes(repo.workspaceScope).Where("assignee_id = ?", 1).Find(&[]entity.Todolist{}).Statement.SQL.String()
+	}
+
+	// tanpa workspace, assigned-to-me tetap mencakup semua todo
+	assert.NotContains(t, query(TodoRepository{}), "workspace_id")
+
+	workspaceID := int64(5)
+	assert.Contains(t, query(TodoRepository{WorkspaceID: &workspaceID}), "assignee_id = ? AND workspace_id = ?")
+}
@@ -9,7 +9,11 @@ import (
 	log "github.com/sirupsen/logrus"
 	"io"
 	"os"
+	"strconv"
+	"time"
+	"todoGin/cfg"
 	"todoGin/database"
+	"todoGin/model/entity"
 	"todoGin/router"
 	"todoGin/service"
 )
@@ -31,6 +35,14 @@ func main() {
 	// ENV
 	loadEnv()
 
+	// secret dan key token dibaca ulang setelah .env dimuat
+	if err := cfg.LoadSigningKeys(); err != nil {
+		log.Fatalf("Error loading JWT keys: %v", err)
+	}
+	if err := cfg.LoadShareLinkKey(); err != nil {
+		log.Fatalf("Error loading share link key: %v", err)
+	}
+
 	// pr
 	// INITAL DATABASE
 	db, err := database.Databaseinit(ctx)
@@ -38,7 +50,7 @@ func main() {
 		return
 	}
 
-	err = database.Migrate(db)
+	err = database.Migrate()
 	if err != nil {
 		log.Fatalf("Error running schema migration %v", err)
 	}
@@ -53,6 +65,31 @@ func main() {
 	// initial repo
 	todoRepo := database.NewTodoRepository(db, s3Client)
 	todoService := service.NewTodoService(todoRepo)
+	// user ADMIN_USERNAME otomatis diberi role admin supaya route /admin bisa dipakai
+	if username := os.Getenv("ADMIN_USERNAME"); username != "" {
+		admin, err := todoRepo.GetUserByUsername(username)
+		if err != nil {
+			log.Warnf("ADMIN_USERNAME %s not found: %v", username, err)
+		} else if _, err := todoRepo.AssignRole(admin.Id, entity.RoleAdmin); err != nil {
+			log.Errorf("failed when assigning admin role: %v", err)
+		}
+	}
+	if spec := os.Getenv("TODO_WORKFLOW"); spec != "" {
+		workflow, err := service.ParseWorkflow(spec)
+		if err != nil {
+			log.Fatalf("Invalid TODO_WORKFLOW: %v", err)
+		}
+		todoService.Workflow = workflow
+	}
+	// purge trash setelah masa retensi (default 30 hari)
+	retentionDays, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || retentionDays <= 0 {
+		retentionDays = 30
+	}
+	todoService.StartTrashPurge(ctx, time.Duration(retentionDays)*24*time.Hour, time.Hour)
+	todoService.StartAutoArchive(ctx, time.Hour)
+	todoService.StartTokenCleanup(ctx, time.Hour)
+
 	routeBuilder := router.NewRouteBuilder(todoService)
 	routeInit := routeBuilder.RouteInit()
 	err = routeInit.Run(":8080")
@@ -1,17 +1,35 @@
 package middleware
 
 import (
-	"github.com/dgrijalva/jwt-go"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/sirupsen/logrus"
 	"net/http"
+	"time"
 	"todoGin/cfg"
+	"todoGin/model/entity"
 	"todoGin/model/respErr"
 )
 
+// AccountChecker dipenuhi oleh service.AccountCache, dipakai untuk mengecek status akun.
+type AccountChecker interface {
+	GetUserByID(userID int64) (*entity.User, error)
+}
+
+// RevocationChecker dipenuhi oleh service.TokenDenylist, mengecek jti token yang sudah logout.
+type RevocationChecker interface {
+	IsRevoked(jti string) (bool, error)
+}
+
+// SessionChecker dipenuhi oleh service.SessionTracker, mengecek sesi dari claim sid.
+type SessionChecker interface {
+	IsActive(sessionID string) (bool, error)
+}
+
 // secret key untuk signing token
 // middleware konsep nya adalah sesuatu yang ibaratnya intercept , request -> server,
-func Authmiddleware() gin.HandlerFunc {
+func Authmiddleware(accounts AccountChecker, revocations RevocationChecker, sessions SessionChecker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// mengambil token dari header Authorization
 		authHeader := ctx.GetHeader("Authorization")
@@ -27,14 +45,12 @@ func Authmiddleware() gin.HandlerFunc {
 		// split token dari header
 		tokenString := authHeader[len("Bearer "):]
 
-		// parsing token dengan secret key
+		// parsing token, key dipilih dari kid atau secret key HS256
 		claims := &cfg.Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return cfg.JwtKey, nil
-		})
+		token, err := jwt.ParseWithClaims(tokenString, claims, cfg.Keyfunc)
 
 		if err != nil {
-			if err == jwt.ErrSignatureInvalid {
+			if errors.Is(err, jwt.ErrSignatureInvalid) {
 				ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
 					Message: "Unauthorized",
 					Status:  http.StatusUnauthorized,
@@ -57,20 +73,90 @@ func Authmiddleware() gin.HandlerFunc {
 			return
 		}
 
-		//// Cek apakah token sudah digunakan sebelumnya
-		//if cfg.UsedTokens[tokenString] {
-		//	ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
-		//		Message: "Token has already been used",
-		//		Status:  http.StatusUnauthorized,
-		//	})
-		//	return
-		//}
-		//// Tandai token sebagai digunakan
-		//cfg.UsedTokens[tokenString] = true
+		// token tanpa jti atau sid tidak bisa di-logout, jadi ditolak,
+		// begitu juga token dengan audience/issuer lain seperti token sementara 2FA
+		if !cfg.VerifyAccessClaims(claims) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
+				Message: "Unauthorized",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+
+		// cek apakah token sudah di-logout
+		isRevoked, err := revocations.IsRevoked(claims.Id)
+		if err != nil {
+			logrus.Errorf("failed when checking token denylist: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, &respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if isRevoked {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
+				Message: "Token has been revoked",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+
+		// sesi yang sudah dicabut dari daftar sesi membuat semua token-nya tidak berlaku
+		isActive, err := sessions.IsActive(claims.SessionID)
+		if err != nil {
+			logrus.Errorf("failed when checking session: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, &respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if !isActive {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
+				Message: "Session has been revoked",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+
+		// token lama tidak berlaku lagi kalau akun sudah dihapus, dinonaktifkan, atau harus reset password
+		account, err := accounts.GetUserByID(claims.UserID)
+		if err != nil {
+			logrus.Errorf("failed when get account: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, &respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if account == nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
+				Message: "Unauthorized",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+		if account.DisabledAt != nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, &respErr.ErrorResponse{
+				Message: "Account is disabled",
+				Status:  http.StatusForbidden,
+			})
+			return
+		}
+		if account.PasswordResetRequired {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, &respErr.ErrorResponse{
+				Message: "Password reset required",
+				Status:  http.StatusForbidden,
+			})
+			return
+		}
 
 		// token valid, ambil username dari claim dan simpan ke dalam konteks
 		ctx.Set("username", claims.Username)
 		ctx.Set("user_id", claims.UserID)
+		ctx.Set("jti", claims.Id)
+		ctx.Set("session_id", claims.SessionID)
+		ctx.Set("token_expires_at", time.Unix(claims.ExpiresAt, 0))
 
 		// token valid, melanjutkan ke handler
 		ctx.Next()
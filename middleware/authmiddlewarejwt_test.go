@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/cfg"
+	"todoGin/model/entity"
+)
+
+// accounts adalah AccountChecker dari map user id ke akun.
+type accounts map[int64]*entity.User
+
+func (a accounts) GetUserByID(userID int64) (*entity.User, error) {
+	if userID == 500 {
+		return nil, errors.New("database down")
+	}
+	return a[userID], nil
+}
+
+// revocations adalah RevocationChecker yang menganggap semua token aktif atau semua sudah logout.
+type revocations struct {
+	revokeAll bool
+	err       error
+}
+
+func (r revocations) IsRevoked(string) (bool, error) {
+	return r.revokeAll, r.err
+}
+
+// sessions adalah SessionChecker dari daftar sesi yang masih aktif.
+type sessions map[string]bool
+
+func (s sessions) IsActive(sessionID string) (bool, error) {
+	return s[sessionID], nil
+}
+
+func serveAuth(t *testing.T, accounts AccountChecker, revoked RevocationChecker, userID int64) *httptest.ResponseRecorder {
+	return serveSession(t, accounts, revoked, sessions{"sesi-1": true}, userID, "sesi-1")
+}
+
+func serveSession(t *testing.T, accounts AccountChecker, revoked RevocationChecker, active SessionChecker, userID int64, sessionID string) *httptest.ResponseRecorder {
+	token, err := cfg.CreateToken("user", userID, sessionID)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/manage-todos", Authmiddleware(accounts, revoked, active), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"user_id": ctx.GetInt64("user_id"), "has_jti": ctx.GetString("jti") != ""})
+	})
+	req, err := http.NewRequest(http.MethodGet, "/manage-todos", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthmiddlewareAccountStatus(t *testing.T) {
+	disabledAt := time.Now()
+	checker := accounts{
+		1: {Id: 1, Username: "aktif"},
+		2: {Id: 2, Username: "nonaktif", DisabledAt: &disabledAt},
+		3: {Id: 3, Username: "reset", PasswordResetRequired: true},
+	}
+	serve := func(userID int64) *httptest.ResponseRecorder {
+		return serveAuth(t, checker, revocations{}, userID)
+	}
+
+	w := serve(1)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user_id": 1, "has_jti": true}`, w.Body.String())
+
+	// token yang dibuat sebelum akun dinonaktifkan tidak berlaku lagi
+	w = serve(2)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Account is disabled")
+
+	w = serve(3)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Password reset required")
+
+	// akun yang sudah dihapus
+	assert.Equal(t, http.StatusUnauthorized, serve(4).Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(500).Code)
+}
+
+func TestAuthmiddlewareRevokedToken(t *testing.T) {
+	checker := accounts{1: {Id: 1, Username: "aktif"}}
+
+	w := serveAuth(t, checker, revocations{revokeAll: true}, 1)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Token has been revoked")
+
+	w = serveAuth(t, checker, revocations{err: errors.New("database down")}, 1)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAuthmiddlewareSession(t *testing.T) {
+	checker := accounts{1: {Id: 1, Username: "aktif"}}
+	active := sessions{"laptop": true, "hp-hilang": false}
+
+	assert.Equal(t, http.StatusOK, serveSession(t, checker, revocations{}, active, 1, "laptop").Code)
+
+	// sesi yang dicabut dari daftar sesi membuat token-nya ikut tidak berlaku
+	w := serveSession(t, checker, revocations{}, active, 1, "hp-hilang")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Session has been revoked")
+
+	// token tanpa sid ditolak
+	assert.Equal(t, http.StatusUnauthorized, serveSession(t, checker, revocations{}, active, 1, "").Code)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/respErr"
+)
+
+// PermissionChecker dipenuhi oleh repository.TodoRepository.
+type PermissionChecker interface {
+	HasPermission(userID int64, permission string) (bool, error)
+}
+
+// RequirePermission menolak request kalau role user tidak punya permission yang diminta.
+// Harus dipasang setelah Authmiddleware karena membaca user_id dari context.
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := ctx.Get("user_id")
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, &respErr.ErrorResponse{
+				Message: "Unauthorized",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+
+		allowed, err := checker.HasPermission(userID.(int64), permission)
+		if err != nil {
+			logrus.Errorf("failed when checking permission %s: %v", permission, err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, &respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if !allowed {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, &respErr.ErrorResponse{
+				Message: "Forbidden",
+				Status:  http.StatusForbidden,
+			})
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// permissions adalah PermissionChecker dari daftar permission per user.
+type permissions map[int64][]string
+
+func (p permissions) HasPermission(userID int64, permission string) (bool, error) {
+	if userID < 0 {
+		return false, errors.New("database down")
+	}
+	for _, granted := range p[userID] {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestRequirePermission(t *testing.T) {
+	checker := permissions{1: {"todos:read_all", "users:read"}, 2: {"users:read"}}
+
+	serve := func(userID interface{}) int {
+		router := gin.New()
+		router.GET("/admin/todos", func(ctx *gin.Context) {
+			if userID != nil {
+				ctx.Set("user_id", userID)
+			}
+		}, RequirePermission(checker, "todos:read_all"), func(ctx *gin.Context) {
+			ctx.Status(http.StatusOK)
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "/admin/todos", nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(int64(1)))
+	assert.Equal(t, http.StatusForbidden, serve(int64(2)))
+	assert.Equal(t, http.StatusForbidden, serve(int64(3)))
+	assert.Equal(t, http.StatusUnauthorized, serve(nil))
+	assert.Equal(t, http.StatusInternalServerError, serve(int64(-1)))
+}
@@ -5,7 +5,17 @@ package mocks
 import (
 	entity "todoGin/model/entity"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
+
+	multipart "mime/multipart"
+
+	repository "todoGin/repository"
+
+	request "todoGin/model/request"
+
+	time "time"
 )
 
 // TodoRepository is an autogenerated mock type for the TodoRepository type
@@ -13,25 +23,49 @@ type TodoRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: title
-func (_m *TodoRepository) Create(title string) (*entity.Todolist, error) {
-	ret := _m.Called(title)
+// AcceptTOTPStep provides a mock function with given fields: userID, step
+func (_m *TodoRepository) AcceptTOTPStep(userID int64, step int64) (bool, error) {
+	ret := _m.Called(userID, step)
 
-	var r0 *entity.Todolist
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (bool, error)); ok {
+		return rf(userID, step)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) bool); ok {
+		r0 = rf(userID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AcceptWorkspaceInvitation provides a mock function with given fields: invitationID, userID
+func (_m *TodoRepository) AcceptWorkspaceInvitation(invitationID int64, userID int64) (*entity.WorkspaceMember, error) {
+	ret := _m.Called(invitationID, userID)
+
+	var r0 *entity.WorkspaceMember
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.Todolist, error)); ok {
-		return rf(title)
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.WorkspaceMember, error)); ok {
+		return rf(invitationID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.Todolist); ok {
-		r0 = rf(title)
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.WorkspaceMember); ok {
+		r0 = rf(invitationID, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Todolist)
+			r0 = ret.Get(0).(*entity.WorkspaceMember)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(title)
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(invitationID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,13 +73,13 @@ func (_m *TodoRepository) Create(title string) (*entity.Todolist, error) {
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: user
-func (_m *TodoRepository) CreateUser(user *entity.User) error {
-	ret := _m.Called(user)
+// AddDependency provides a mock function with given fields: todoID, blockedByID, userID
+func (_m *TodoRepository) AddDependency(todoID int64, blockedByID int64, userID int64) error {
+	ret := _m.Called(todoID, blockedByID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(int64, int64, int64) error); ok {
+		r0 = rf(todoID, blockedByID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -53,23 +87,37 @@ func (_m *TodoRepository) CreateUser(user *entity.User) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: todoID
-func (_m *TodoRepository) Delete(todoID int64) (int64, error) {
-	ret := _m.Called(todoID)
+// AddHistory provides a mock function with given fields: history
+func (_m *TodoRepository) AddHistory(history *entity.TodoHistory) error {
+	ret := _m.Called(history)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.TodoHistory) error); ok {
+		r0 = rf(history)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchiveDoneTodos provides a mock function with given fields: now
+func (_m *TodoRepository) ArchiveDoneTodos(now time.Time) (int64, error) {
+	ret := _m.Called(now)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
-		return rf(todoID)
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(now)
 	}
-	if rf, ok := ret.Get(0).(func(int64) int64); ok {
-		r0 = rf(todoID)
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(todoID)
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,25 +125,77 @@ func (_m *TodoRepository) Delete(todoID int64) (int64, error) {
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields:
-func (_m *TodoRepository) GetAll() ([]entity.Todolist, error) {
-	ret := _m.Called()
+// AssignRole provides a mock function with given fields: userID, roleName
+func (_m *TodoRepository) AssignRole(userID int64, roleName string) (bool, error) {
+	ret := _m.Called(userID, roleName)
 
-	var r0 []entity.Todolist
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(userID, roleName)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(userID, roleName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignTodo provides a mock function with given fields: todoID, assigneeID
+func (_m *TodoRepository) AssignTodo(todoID int64, assigneeID *int64) error {
+	ret := _m.Called(todoID, assigneeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *int64) error); ok {
+		r0 = rf(todoID, assigneeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachLabel provides a mock function with given fields: todoID, labelID
+func (_m *TodoRepository) AttachLabel(todoID int64, labelID int64) error {
+	ret := _m.Called(todoID, labelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(todoID, labelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeStatus provides a mock function with given fields: todoID, from, to, updates, next
+func (_m *TodoRepository) ChangeStatus(todoID int64, from string, to string, updates map[string]interface{}, next *entity.Todolist) (*entity.StatusTransition, error) {
+	ret := _m.Called(todoID, from, to, updates, next)
+
+	var r0 *entity.StatusTransition
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]entity.Todolist, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(int64, string, string, map[string]interface{}, *entity.Todolist) (*entity.StatusTransition, error)); ok {
+		return rf(todoID, from, to, updates, next)
 	}
-	if rf, ok := ret.Get(0).(func() []entity.Todolist); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(int64, string, string, map[string]interface{}, *entity.Todolist) *entity.StatusTransition); ok {
+		r0 = rf(todoID, from, to, updates, next)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Todolist)
+			r0 = ret.Get(0).(*entity.StatusTransition)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int64, string, string, map[string]interface{}, *entity.Todolist) error); ok {
+		r1 = rf(todoID, from, to, updates, next)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,25 +203,49 @@ func (_m *TodoRepository) GetAll() ([]entity.Todolist, error) {
 	return r0, r1
 }
 
-// GetByID provides a mock function with given fields: todoID
-func (_m *TodoRepository) GetByID(todoID int64) (*entity.Todolist, error) {
-	ret := _m.Called(todoID)
+// CountRecoveryCodes provides a mock function with given fields: userID
+func (_m *TodoRepository) CountRecoveryCodes(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: todo
+func (_m *TodoRepository) Create(todo *entity.Todolist) (*entity.Todolist, error) {
+	ret := _m.Called(todo)
 
 	var r0 *entity.Todolist
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*entity.Todolist, error)); ok {
-		return rf(todoID)
+	if rf, ok := ret.Get(0).(func(*entity.Todolist) (*entity.Todolist, error)); ok {
+		return rf(todo)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *entity.Todolist); ok {
-		r0 = rf(todoID)
+	if rf, ok := ret.Get(0).(func(*entity.Todolist) *entity.Todolist); ok {
+		r0 = rf(todo)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Todolist)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(todoID)
+	if rf, ok := ret.Get(1).(func(*entity.Todolist) error); ok {
+		r1 = rf(todo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,25 +253,25 @@ func (_m *TodoRepository) GetByID(todoID int64) (*entity.Todolist, error) {
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: username
-func (_m *TodoRepository) GetUserByUsername(username string) (*entity.User, error) {
-	ret := _m.Called(username)
+// CreateAttachment provides a mock function with given fields: todoID, path, order
+func (_m *TodoRepository) CreateAttachment(todoID int64, path string, order int64) (*entity.Attachment, error) {
+	ret := _m.Called(todoID, path, order)
 
-	var r0 *entity.User
+	var r0 *entity.Attachment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.User, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(int64, string, int64) (*entity.Attachment, error)); ok {
+		return rf(todoID, path, order)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(int64, string, int64) *entity.Attachment); ok {
+		r0 = rf(todoID, path, order)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
+			r0 = ret.Get(0).(*entity.Attachment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(int64, string, int64) error); ok {
+		r1 = rf(todoID, path, order)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -155,25 +279,2227 @@ func (_m *TodoRepository) GetUserByUsername(username string) (*entity.User, erro
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: todoID, updates
-func (_m *TodoRepository) Update(todoID int64, updates map[string]interface{}) (*entity.Todolist, error) {
-	ret := _m.Called(todoID, updates)
+// CreateChecklistItem provides a mock function with given fields: item
+func (_m *TodoRepository) CreateChecklistItem(item *entity.ChecklistItem) error {
+	ret := _m.Called(item)
 
-	var r0 *entity.Todolist
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.ChecklistItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateComment provides a mock function with given fields: comment
+func (_m *TodoRepository) CreateComment(comment *entity.Comment) error {
+	ret := _m.Called(comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Comment) error); ok {
+		r0 = rf(comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLabel provides a mock function with given fields: label
+func (_m *TodoRepository) CreateLabel(label *entity.Label) error {
+	ret := _m.Called(label)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Label) error); ok {
+		r0 = rf(label)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateProject provides a mock function with given fields: project
+func (_m *TodoRepository) CreateProject(project *entity.Project) error {
+	ret := _m.Called(project)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Project) error); ok {
+		r0 = rf(project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefreshToken provides a mock function with given fields: token
+func (_m *TodoRepository) CreateRefreshToken(token *entity.RefreshToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.RefreshToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSession provides a mock function with given fields: session
+func (_m *TodoRepository) CreateSession(session *entity.Session) error {
+	ret := _m.Called(session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Session) error); ok {
+		r0 = rf(session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateShareLink provides a mock function with given fields: link
+func (_m *TodoRepository) CreateShareLink(link *entity.ShareLink) error {
+	ret := _m.Called(link)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.ShareLink) error); ok {
+		r0 = rf(link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: user
+func (_m *TodoRepository) CreateUser(user *entity.User) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWorkspace provides a mock function with given fields: workspace
+func (_m *TodoRepository) CreateWorkspace(workspace *entity.Workspace) error {
+	ret := _m.Called(workspace)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Workspace) error); ok {
+		r0 = rf(workspace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWorkspaceInvitation provides a mock function with given fields: invitation
+func (_m *TodoRepository) CreateWorkspaceInvitation(invitation *entity.WorkspaceInvitation) error {
+	ret := _m.Called(invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.WorkspaceInvitation) error); ok {
+		r0 = rf(invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) Delete(todoID int64, userID int64) (int64, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, map[string]interface{}) (*entity.Todolist, error)); ok {
-		return rf(todoID, updates)
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, userID)
 	}
-	if rf, ok := ret.Get(0).(func(int64, map[string]interface{}) *entity.Todolist); ok {
-		r0 = rf(todoID, updates)
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Todolist)
-		}
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteChecklistItem provides a mock function with given fields: itemID, todoID
+func (_m *TodoRepository) DeleteChecklistItem(itemID int64, todoID int64) (int64, error) {
+	ret := _m.Called(itemID, todoID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(itemID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(itemID, todoID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(itemID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteComment provides a mock function with given fields: commentID
+func (_m *TodoRepository) DeleteComment(commentID int64) (int64, error) {
+	ret := _m.Called(commentID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(commentID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(commentID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredRefreshTokens provides a mock function with given fields: before
+func (_m *TodoRepository) DeleteExpiredRefreshTokens(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredRevokedTokens provides a mock function with given fields: before
+func (_m *TodoRepository) DeleteExpiredRevokedTokens(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLabel provides a mock function with given fields: labelID, userID
+func (_m *TodoRepository) DeleteLabel(labelID int64, userID int64) (int64, error) {
+	ret := _m.Called(labelID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(labelID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(labelID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(labelID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteProject provides a mock function with given fields: projectID, userID
+func (_m *TodoRepository) DeleteProject(projectID int64, userID int64) (int64, error) {
+	ret := _m.Called(projectID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteStaleSessions provides a mock function with given fields: idleBefore, revokedBefore
+func (_m *TodoRepository) DeleteStaleSessions(idleBefore time.Time, revokedBefore time.Time) (int64, error) {
+	ret := _m.Called(idleBefore, revokedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) (int64, error)); ok {
+		return rf(idleBefore, revokedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int64); ok {
+		r0 = rf(idleBefore, revokedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(idleBefore, revokedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: userID
+func (_m *TodoRepository) DeleteUser(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DetachLabel provides a mock function with given fields: todoID, labelID
+func (_m *TodoRepository) DetachLabel(todoID int64, labelID int64) (int64, error) {
+	ret := _m.Called(todoID, labelID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, labelID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, labelID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, labelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: userID
+func (_m *TodoRepository) DisableTOTP(userID int64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTOTP provides a mock function with given fields: userID, codeHashes
+func (_m *TodoRepository) EnableTOTP(userID int64, codeHashes []string) error {
+	ret := _m.Called(userID, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
+		r0 = rf(userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveSessions provides a mock function with given fields: userID
+func (_m *TodoRepository) GetActiveSessions(userID int64) ([]entity.Session, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Session, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Session); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveShareLinks provides a mock function with given fields: userID, now
+func (_m *TodoRepository) GetActiveShareLinks(userID int64, now time.Time) ([]entity.ShareLink, error) {
+	ret := _m.Called(userID, now)
+
+	var r0 []entity.ShareLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, time.Time) ([]entity.ShareLink, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(int64, time.Time) []entity.ShareLink); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ShareLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, time.Time) error); ok {
+		r1 = rf(userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: page, perPage
+func (_m *TodoRepository) GetAll(page int, perPage int) ([]entity.Todolist, int64, error) {
+	ret := _m.Called(page, perPage)
+
+	var r0 []entity.Todolist
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]entity.Todolist, int64, error)); ok {
+		return rf(page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) []entity.Todolist); ok {
+		r0 = rf(page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) int64); ok {
+		r1 = rf(page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAllUserByID provides a mock function with given fields: UserID, filter
+func (_m *TodoRepository) GetAllUserByID(UserID int64, filter request.TodoFilter) ([]entity.Todolist, error) {
+	ret := _m.Called(UserID, filter)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, request.TodoFilter) ([]entity.Todolist, error)); ok {
+		return rf(UserID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(int64, request.TodoFilter) []entity.Todolist); ok {
+		r0 = rf(UserID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, request.TodoFilter) error); ok {
+		r1 = rf(UserID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArchivedByUser provides a mock function with given fields: userID, page, perPage
+func (_m *TodoRepository) GetArchivedByUser(userID int64, page int, perPage int) ([]entity.Todolist, int64, error) {
+	ret := _m.Called(userID, page, perPage)
+
+	var r0 []entity.Todolist
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]entity.Todolist, int64, error)); ok {
+		return rf(userID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []entity.Todolist); ok {
+		r0 = rf(userID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) int64); ok {
+		r1 = rf(userID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, int, int) error); ok {
+		r2 = rf(userID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAssignedToUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetAssignedToUser(userID int64) ([]entity.Todolist, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Todolist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Todolist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) GetByID(todoID int64, userID int64) (*entity.Todolist, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 *entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.Todolist, error)); ok {
+		return rf(todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.Todolist); ok {
+		r0 = rf(todoID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecklistItem provides a mock function with given fields: itemID, todoID
+func (_m *TodoRepository) GetChecklistItem(itemID int64, todoID int64) (*entity.ChecklistItem, error) {
+	ret := _m.Called(itemID, todoID)
+
+	var r0 *entity.ChecklistItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.ChecklistItem, error)); ok {
+		return rf(itemID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.ChecklistItem); ok {
+		r0 = rf(itemID, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ChecklistItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(itemID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComment provides a mock function with given fields: commentID, todoID
+func (_m *TodoRepository) GetComment(commentID int64, todoID int64) (*entity.Comment, error) {
+	ret := _m.Called(commentID, todoID)
+
+	var r0 *entity.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.Comment, error)); ok {
+		return rf(commentID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.Comment); ok {
+		r0 = rf(commentID, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(commentID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComments provides a mock function with given fields: todoID, page, perPage
+func (_m *TodoRepository) GetComments(todoID int64, page int, perPage int) ([]entity.Comment, int64, error) {
+	ret := _m.Called(todoID, page, perPage)
+
+	var r0 []entity.Comment
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]entity.Comment, int64, error)); ok {
+		return rf(todoID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []entity.Comment); ok {
+		r0 = rf(todoID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) int64); ok {
+		r1 = rf(todoID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, int, int) error); ok {
+		r2 = rf(todoID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDueBetween provides a mock function with given fields: userID, from, to
+func (_m *TodoRepository) GetDueBetween(userID int64, from *time.Time, to time.Time) ([]entity.Todolist, error) {
+	ret := _m.Called(userID, from, to)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *time.Time, time.Time) ([]entity.Todolist, error)); ok {
+		return rf(userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *time.Time, time.Time) []entity.Todolist); ok {
+		r0 = rf(userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *time.Time, time.Time) error); ok {
+		r1 = rf(userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: todoID, page, perPage
+func (_m *TodoRepository) GetHistory(todoID int64, page int, perPage int) ([]entity.TodoHistory, int64, error) {
+	ret := _m.Called(todoID, page, perPage)
+
+	var r0 []entity.TodoHistory
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]entity.TodoHistory, int64, error)); ok {
+		return rf(todoID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []entity.TodoHistory); ok {
+		r0 = rf(todoID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TodoHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) int64); ok {
+		r1 = rf(todoID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, int, int) error); ok {
+		r2 = rf(todoID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLabelByID provides a mock function with given fields: labelID, userID
+func (_m *TodoRepository) GetLabelByID(labelID int64, userID int64) (*entity.Label, error) {
+	ret := _m.Called(labelID, userID)
+
+	var r0 *entity.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.Label, error)); ok {
+		return rf(labelID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.Label); ok {
+		r0 = rf(labelID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(labelID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLabelByName provides a mock function with given fields: userID, name
+func (_m *TodoRepository) GetLabelByName(userID int64, name string) (*entity.Label, error) {
+	ret := _m.Called(userID, name)
+
+	var r0 *entity.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (*entity.Label, error)); ok {
+		return rf(userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) *entity.Label); ok {
+		r0 = rf(userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLabelsByUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetLabelsByUser(userID int64) ([]entity.Label, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Label, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Label); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingInvitations provides a mock function with given fields: userID
+func (_m *TodoRepository) GetPendingInvitations(userID int64) ([]entity.WorkspaceInvitation, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.WorkspaceInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.WorkspaceInvitation, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.WorkspaceInvitation); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WorkspaceInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectByID provides a mock function with given fields: projectID, userID
+func (_m *TodoRepository) GetProjectByID(projectID int64, userID int64) (*entity.Project, error) {
+	ret := _m.Called(projectID, userID)
+
+	var r0 *entity.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.Project, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.Project); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectShares provides a mock function with given fields: projectID
+func (_m *TodoRepository) GetProjectShares(projectID int64) ([]entity.ProjectShare, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []entity.ProjectShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.ProjectShare, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.ProjectShare); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ProjectShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectsByUser provides a mock function with given fields: userID, includeArchived
+func (_m *TodoRepository) GetProjectsByUser(userID int64, includeArchived bool) ([]entity.Project, error) {
+	ret := _m.Called(userID, includeArchived)
+
+	var r0 []entity.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, bool) ([]entity.Project, error)); ok {
+		return rf(userID, includeArchived)
+	}
+	if rf, ok := ret.Get(0).(func(int64, bool) []entity.Project); ok {
+		r0 = rf(userID, includeArchived)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(userID, includeArchived)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectsSharedWithUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetProjectsSharedWithUser(userID int64) ([]entity.Project, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Project, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Project); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicTodo provides a mock function with given fields: todoID
+func (_m *TodoRepository) GetPublicTodo(todoID int64) (*entity.Todolist, error) {
+	ret := _m.Called(todoID)
+
+	var r0 *entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*entity.Todolist, error)); ok {
+		return rf(todoID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *entity.Todolist); ok {
+		r0 = rf(todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshToken provides a mock function with given fields: tokenHash
+func (_m *TodoRepository) GetRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 *entity.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entity.RefreshToken, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entity.RefreshToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevokedToken provides a mock function with given fields: jti
+func (_m *TodoRepository) GetRevokedToken(jti string) (*entity.RevokedToken, error) {
+	ret := _m.Called(jti)
+
+	var r0 *entity.RevokedToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entity.RevokedToken, error)); ok {
+		return rf(jti)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entity.RevokedToken); ok {
+		r0 = rf(jti)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RevokedToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jti)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRolesByUsers provides a mock function with given fields: userIDs
+func (_m *TodoRepository) GetRolesByUsers(userIDs []int64) (map[int64][]string, error) {
+	ret := _m.Called(userIDs)
+
+	var r0 map[int64][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int64) (map[int64][]string, error)); ok {
+		return rf(userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]int64) map[int64][]string); ok {
+		r0 = rf(userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = rf(userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShareLink provides a mock function with given fields: linkID
+func (_m *TodoRepository) GetShareLink(linkID int64) (*entity.ShareLink, error) {
+	ret := _m.Called(linkID)
+
+	var r0 *entity.ShareLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*entity.ShareLink, error)); ok {
+		return rf(linkID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *entity.ShareLink); ok {
+		r0 = rf(linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ShareLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSharedWithUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetSharedWithUser(userID int64) ([]entity.Todolist, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Todolist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Todolist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTodoShares provides a mock function with given fields: todoID
+func (_m *TodoRepository) GetTodoShares(todoID int64) ([]entity.TodoShare, error) {
+	ret := _m.Called(todoID)
+
+	var r0 []entity.TodoShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.TodoShare, error)); ok {
+		return rf(todoID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.TodoShare); ok {
+		r0 = rf(todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TodoShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrashByUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetTrashByUser(userID int64) ([]entity.Todolist, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Todolist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Todolist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrashedTodo provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) GetTrashedTodo(todoID int64, userID int64) (*entity.Todolist, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 *entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.Todolist, error)); ok {
+		return rf(todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.Todolist); ok {
+		r0 = rf(todoID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: userID
+func (_m *TodoRepository) GetUserByID(userID int64) (*entity.User, error) {
+	ret := _m.Called(userID)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*entity.User, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *entity.User); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByUsername provides a mock function with given fields: username
+func (_m *TodoRepository) GetUserByUsername(username string) (*entity.User, error) {
+	ret := _m.Called(username)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entity.User, error)); ok {
+		return rf(username)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
+		r0 = rf(username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: query, page, perPage
+func (_m *TodoRepository) GetUsers(query string, page int, perPage int) ([]entity.User, int64, error) {
+	ret := _m.Called(query, page, perPage)
+
+	var r0 []entity.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]entity.User, int64, error)); ok {
+		return rf(query, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []entity.User); ok {
+		r0 = rf(query, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
+		r1 = rf(query, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
+		r2 = rf(query, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetWithoutDueDate provides a mock function with given fields: userID
+func (_m *TodoRepository) GetWithoutDueDate(userID int64) ([]entity.Todolist, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Todolist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Todolist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceMember provides a mock function with given fields: workspaceID, userID
+func (_m *TodoRepository) GetWorkspaceMember(workspaceID int64, userID int64) (*entity.WorkspaceMember, error) {
+	ret := _m.Called(workspaceID, userID)
+
+	var r0 *entity.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (*entity.WorkspaceMember, error)); ok {
+		return rf(workspaceID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) *entity.WorkspaceMember); ok {
+		r0 = rf(workspaceID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(workspaceID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceMembers provides a mock function with given fields: workspaceID
+func (_m *TodoRepository) GetWorkspaceMembers(workspaceID int64) ([]entity.WorkspaceMember, error) {
+	ret := _m.Called(workspaceID)
+
+	var r0 []entity.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.WorkspaceMember, error)); ok {
+		return rf(workspaceID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.WorkspaceMember); ok {
+		r0 = rf(workspaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(workspaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspacesByUser provides a mock function with given fields: userID
+func (_m *TodoRepository) GetWorkspacesByUser(userID int64) ([]entity.Workspace, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entity.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]entity.Workspace, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []entity.Workspace); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasPermission provides a mock function with given fields: userID, permission
+func (_m *TodoRepository) HasPermission(userID int64, permission string) (bool, error) {
+	ret := _m.Called(userID, permission)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(userID, permission)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(userID, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InWorkspace provides a mock function with given fields: workspaceID, role
+func (_m *TodoRepository) InWorkspace(workspaceID int64, role string) repository.TodoRepository {
+	ret := _m.Called(workspaceID, role)
+
+	var r0 repository.TodoRepository
+	if rf, ok := ret.Get(0).(func(int64, string) repository.TodoRepository); ok {
+		r0 = rf(workspaceID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.TodoRepository)
+		}
+	}
+
+	return r0
+}
+
+// IsAssignable provides a mock function with given fields: todo, userID
+func (_m *TodoRepository) IsAssignable(todo *entity.Todolist, userID int64) (bool, error) {
+	ret := _m.Called(todo, userID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entity.Todolist, int64) (bool, error)); ok {
+		return rf(todo, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*entity.Todolist, int64) bool); ok {
+		r0 = rf(todo, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*entity.Todolist, int64) error); ok {
+		r1 = rf(todo, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveTodo provides a mock function with given fields: todoID, userID, targetID, after
+func (_m *TodoRepository) MoveTodo(todoID int64, userID int64, targetID int64, after bool) (string, error) {
+	ret := _m.Called(todoID, userID, targetID, after)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int64, bool) (string, error)); ok {
+		return rf(todoID, userID, targetID, after)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int64, bool) string); ok {
+		r0 = rf(todoID, userID, targetID, after)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int64, bool) error); ok {
+		r1 = rf(todoID, userID, targetID, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTodo provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) PurgeTodo(todoID int64, userID int64) (int64, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: before
+func (_m *TodoRepository) PurgeTrash(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDependency provides a mock function with given fields: todoID, blockedByID
+func (_m *TodoRepository) RemoveDependency(todoID int64, blockedByID int64) (int64, error) {
+	ret := _m.Called(todoID, blockedByID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, blockedByID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, blockedByID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, blockedByID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveProjectShare provides a mock function with given fields: projectID, userID
+func (_m *TodoRepository) RemoveProjectShare(projectID int64, userID int64) (int64, error) {
+	ret := _m.Called(projectID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(projectID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(projectID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveRole provides a mock function with given fields: userID, roleName
+func (_m *TodoRepository) RemoveRole(userID int64, roleName string) (int64, error) {
+	ret := _m.Called(userID, roleName)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (int64, error)); ok {
+		return rf(userID, roleName)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) int64); ok {
+		r0 = rf(userID, roleName)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTodoShare provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) RemoveTodoShare(todoID int64, userID int64) (int64, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveWorkspaceMember provides a mock function with given fields: workspaceID, userID
+func (_m *TodoRepository) RemoveWorkspaceMember(workspaceID int64, userID int64) (int64, error) {
+	ret := _m.Called(workspaceID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(workspaceID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(workspaceID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(workspaceID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetMFAFailures provides a mock function with given fields: userID
+func (_m *TodoRepository) ResetMFAFailures(userID int64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreTodo provides a mock function with given fields: todoID, userID
+func (_m *TodoRepository) RestoreTodo(todoID int64, userID int64) (int64, error) {
+	ret := _m.Called(todoID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(todoID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: sessionID, userID
+func (_m *TodoRepository) RevokeSession(sessionID string, userID int64) (int64, error) {
+	ret := _m.Called(sessionID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (int64, error)); ok {
+		return rf(sessionID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(sessionID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(sessionID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeShareLink provides a mock function with given fields: linkID, userID
+func (_m *TodoRepository) RevokeShareLink(linkID int64, userID int64) (int64, error) {
+	ret := _m.Called(linkID, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(linkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(linkID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(linkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: token
+func (_m *TodoRepository) RevokeToken(token *entity.RevokedToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.RevokedToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSessions provides a mock function with given fields: userID, exceptSessionID
+func (_m *TodoRepository) RevokeUserSessions(userID int64, exceptSessionID string) (int64, error) {
+	ret := _m.Called(userID, exceptSessionID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (int64, error)); ok {
+		return rf(userID, exceptSessionID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) int64); ok {
+		r0 = rf(userID, exceptSessionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, exceptSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateRefreshToken provides a mock function with given fields: oldID, next
+func (_m *TodoRepository) RotateRefreshToken(oldID int64, next *entity.RefreshToken) (bool, error) {
+	ret := _m.Called(oldID, next)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *entity.RefreshToken) (bool, error)); ok {
+		return rf(oldID, next)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *entity.RefreshToken) bool); ok {
+		r0 = rf(oldID, next)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *entity.RefreshToken) error); ok {
+		r1 = rf(oldID, next)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTodolistByUser provides a mock function with given fields: userID, search, page, perPage, filter
+func (_m *TodoRepository) SearchTodolistByUser(userID int64, search string, page int, perPage int, filter request.TodoFilter) ([]entity.Todolist, int64, error) {
+	ret := _m.Called(userID, search, page, perPage, filter)
+
+	var r0 []entity.Todolist
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, string, int, int, request.TodoFilter) ([]entity.Todolist, int64, error)); ok {
+		return rf(userID, search, page, perPage, filter)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, int, int, request.TodoFilter) []entity.Todolist); ok {
+		r0 = rf(userID, search, page, perPage, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, int, int, request.TodoFilter) int64); ok {
+		r1 = rf(userID, search, page, perPage, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, string, int, int, request.TodoFilter) error); ok {
+		r2 = rf(userID, search, page, perPage, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetArchived provides a mock function with given fields: todoID, userID, archived
+func (_m *TodoRepository) SetArchived(todoID int64, userID int64, archived bool) (int64, error) {
+	ret := _m.Called(todoID, userID, archived)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) (int64, error)); ok {
+		return rf(todoID, userID, archived)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) int64); ok {
+		r0 = rf(todoID, userID, archived)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) error); ok {
+		r1 = rf(todoID, userID, archived)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTOTPSecret provides a mock function with given fields: userID, secret
+func (_m *TodoRepository) SetTOTPSecret(userID int64, secret string) error {
+	ret := _m.Called(userID, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserDisabled provides a mock function with given fields: userID, disabled
+func (_m *TodoRepository) SetUserDisabled(userID int64, disabled bool) (int64, error) {
+	ret := _m.Called(userID, disabled)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, bool) (int64, error)); ok {
+		return rf(userID, disabled)
+	}
+	if rf, ok := ret.Get(0).(func(int64, bool) int64); ok {
+		r0 = rf(userID, disabled)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(userID, disabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareProject provides a mock function with given fields: share
+func (_m *TodoRepository) ShareProject(share *entity.ProjectShare) error {
+	ret := _m.Called(share)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.ProjectShare) error); ok {
+		r0 = rf(share)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShareTodo provides a mock function with given fields: share
+func (_m *TodoRepository) ShareTodo(share *entity.TodoShare) error {
+	ret := _m.Called(share)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.TodoShare) error); ok {
+		r0 = rf(share)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartMFAAttempt provides a mock function with given fields: userID, now, maxAttempts, lockUntil
+func (_m *TodoRepository) StartMFAAttempt(userID int64, now time.Time, maxAttempts int, lockUntil time.Time) (bool, error) {
+	ret := _m.Called(userID, now, maxAttempts, lockUntil)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, time.Time, int, time.Time) (bool, error)); ok {
+		return rf(userID, now, maxAttempts, lockUntil)
+	}
+	if rf, ok := ret.Get(0).(func(int64, time.Time, int, time.Time) bool); ok {
+		r0 = rf(userID, now, maxAttempts, lockUntil)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, time.Time, int, time.Time) error); ok {
+		r1 = rf(userID, now, maxAttempts, lockUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TouchSession provides a mock function with given fields: sessionID, now
+func (_m *TodoRepository) TouchSession(sessionID string, now time.Time) (bool, error) {
+	ret := _m.Called(sessionID, now)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (bool, error)); ok {
+		return rf(sessionID, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) bool); ok {
+		r0 = rf(sessionID, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(sessionID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: todoID, userID, updates
+func (_m *TodoRepository) Update(todoID int64, userID int64, updates map[string]interface{}) (*entity.Todolist, error) {
+	ret := _m.Called(todoID, userID, updates)
+
+	var r0 *entity.Todolist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, map[string]interface{}) (*entity.Todolist, error)); ok {
+		return rf(todoID, userID, updates)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, map[string]interface{}) *entity.Todolist); ok {
+		r0 = rf(todoID, userID, updates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Todolist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, map[string]interface{}) error); ok {
+		r1 = rf(todoID, userID, updates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAutoArchiveDays provides a mock function with given fields: userID, days
+func (_m *TodoRepository) UpdateAutoArchiveDays(userID int64, days int) error {
+	ret := _m.Called(userID, days)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int) error); ok {
+		r0 = rf(userID, days)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateChecklistItem provides a mock function with given fields: itemID, todoID, updates
+func (_m *TodoRepository) UpdateChecklistItem(itemID int64, todoID int64, updates map[string]interface{}) error {
+	ret := _m.Called(itemID, todoID, updates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, map[string]interface{}) error); ok {
+		r0 = rf(itemID, todoID, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateComment provides a mock function with given fields: commentID, body
+func (_m *TodoRepository) UpdateComment(commentID int64, body string) error {
+	ret := _m.Called(commentID, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(commentID, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLabel provides a mock function with given fields: labelID, userID, updates
+func (_m *TodoRepository) UpdateLabel(labelID int64, userID int64, updates map[string]interface{}) error {
+	ret := _m.Called(labelID, userID, updates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, map[string]interface{}) error); ok {
+		r0 = rf(labelID, userID, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: userID, hashedPassword, resetRequired
+func (_m *TodoRepository) UpdatePassword(userID int64, hashedPassword string, resetRequired bool) (int64, error) {
+	ret := _m.Called(userID, hashedPassword, resetRequired)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, bool) (int64, error)); ok {
+		return rf(userID, hashedPassword, resetRequired)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, bool) int64); ok {
+		r0 = rf(userID, hashedPassword, resetRequired)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, bool) error); ok {
+		r1 = rf(userID, hashedPassword, resetRequired)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProject provides a mock function with given fields: projectID, userID, updates
+func (_m *TodoRepository) UpdateProject(projectID int64, userID int64, updates map[string]interface{}) error {
+	ret := _m.Called(projectID, userID, updates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, map[string]interface{}) error); ok {
+		r0 = rf(projectID, userID, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTodoWithAttachments provides a mock function with given fields: todo
+func (_m *TodoRepository) UpdateTodoWithAttachments(todo *entity.Todolist) error {
+	ret := _m.Called(todo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Todolist) error); ok {
+		r0 = rf(todo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWorkspaceMemberRole provides a mock function with given fields: workspaceID, userID, role
+func (_m *TodoRepository) UpdateWorkspaceMemberRole(workspaceID int64, userID int64, role string) (int64, error) {
+	ret := _m.Called(workspaceID, userID, role)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, string) (int64, error)); ok {
+		return rf(workspaceID, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, string) int64); ok {
+		r0 = rf(workspaceID, userID, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, string) error); ok {
+		r1 = rf(workspaceID, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatetoAtch provides a mock function with given fields: attachment
+func (_m *TodoRepository) UpdatetoAtch(attachment *entity.Attachment) error {
+	ret := _m.Called(attachment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Attachment) error); ok {
+		r0 = rf(attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadFileS3Buckets provides a mock function with given fields: file, fileName
+func (_m *TodoRepository) UploadFileS3Buckets(file io.Reader, fileName string) (*string, error) {
+	ret := _m.Called(file, fileName)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(io.Reader, string) (*string, error)); ok {
+		return rf(file, fileName)
+	}
+	if rf, ok := ret.Get(0).(func(io.Reader, string) *string); ok {
+		r0 = rf(file, fileName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(io.Reader, string) error); ok {
+		r1 = rf(file, fileName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadTodoFileLocalAtch provides a mock function with given fields: file, todoID, userID
+func (_m *TodoRepository) UploadTodoFileLocalAtch(file *multipart.FileHeader, todoID int64, userID int64) (*entity.Attachment, error) {
+	ret := _m.Called(file, todoID, userID)
+
+	var r0 *entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, int64, int64) (*entity.Attachment, error)); ok {
+		return rf(file, todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, int64, int64) *entity.Attachment); ok {
+		r0 = rf(file, todoID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, int64, int64) error); ok {
+		r1 = rf(file, todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadTodoFileS3Atch provides a mock function with given fields: file, todoID, userID
+func (_m *TodoRepository) UploadTodoFileS3Atch(file *multipart.FileHeader, todoID int64, userID int64) (*entity.Attachment, error) {
+	ret := _m.Called(file, todoID, userID)
+
+	var r0 *entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, int64, int64) (*entity.Attachment, error)); ok {
+		return rf(file, todoID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*multipart.FileHeader, int64, int64) *entity.Attachment); ok {
+		r0 = rf(file, todoID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*multipart.FileHeader, int64, int64) error); ok {
+		r1 = rf(file, todoID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseRecoveryCode provides a mock function with given fields: userID, codeHash
+func (_m *TodoRepository) UseRecoveryCode(userID int64, codeHash string) (bool, error) {
+	ret := _m.Called(userID, codeHash)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, map[string]interface{}) error); ok {
-		r1 = rf(todoID, updates)
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, codeHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type ChecklistItem struct {
+	ID          int64      `gorm:"primaryKey" json:"id"`
+	TodoID      int64      `gorm:"index" json:"todo_id"`
+	Title       string     `gorm:"type:varchar(300)" json:"title"`
+	Done        bool       `gorm:"default:false" json:"done"`
+	ItemOrder   int64      `json:"item_order"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// ChecklistProgress adalah ringkasan checklist sebuah todo, misal 3/5 done.
+type ChecklistProgress struct {
+	Done  int64 `json:"done"`
+	Total int64 `json:"total"`
+}
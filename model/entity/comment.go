@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type Comment struct {
+	ID             int64      `gorm:"primaryKey" json:"id"`
+	TodoID         int64      `gorm:"index" json:"todo_id"`
+	UserID         int64      `json:"user_id"`
+	AuthorUsername string     `gorm:"->" json:"author_username"`
+	Body           string     `gorm:"type:text" json:"body"`
+	CreatedAt      time.Time  `json:"created_at"`
+	EditedAt       *time.Time `json:"edited_at"`
+}
+
+func (Comment) TableName() string {
+	return "todo_comments"
+}
@@ -0,0 +1,8 @@
+package entity
+
+type Label struct {
+	ID     int64  `gorm:"primaryKey" json:"id"`
+	UserID int64  `gorm:"index" json:"-"`
+	Name   string `gorm:"type:varchar(50)" json:"name"`
+	Color  string `gorm:"type:varchar(20)" json:"color"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/sirupsen/logrus"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdownRenderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	htmlPolicy       = bluemonday.UGCPolicy()
+)
+
+// RenderDescription mengubah deskripsi markdown menjadi HTML yang sudah disanitasi,
+// jadi aman ditampilkan langsung oleh client.
+func RenderDescription(markdown string) string {
+	if markdown == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(markdown), &buf); err != nil {
+		logrus.Errorf("failed when rendering description: %v", err)
+		return ""
+	}
+	return htmlPolicy.Sanitize(buf.String())
+}
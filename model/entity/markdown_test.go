@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRenderDescription(t *testing.T) {
+	assert.Equal(t, "", RenderDescription(""))
+
+	html := RenderDescription("# Catatan\n\n- [x] beli **susu**\n\n| a | b |\n|---|---|\n| 1 | 2 |")
+	assert.Contains(t, html, "<h1>Catatan</h1>")
+	assert.Contains(t, html, "<strong>susu</strong>")
+	assert.Contains(t, html, "<table>", "GFM tables are rendered")
+
+	// HTML dari user tidak boleh lolos ke client
+	for _, markdown := range []string{
+		"<script>alert(1)</script>",
+		`<img src=x onerror="alert(1)">`,
+		"[klik](javascript:alert(1))",
+		`<a href="#" onclick="alert(1)">x</a>`,
+	} {
+		html := RenderDescription(markdown)
+		assert.NotContains(t, strings.ToLower(html), "<script", markdown)
+		assert.NotContains(t, strings.ToLower(html), "onerror", markdown)
+		assert.NotContains(t, strings.ToLower(html), "onclick", markdown)
+		assert.NotContains(t, strings.ToLower(html), "javascript:", markdown)
+	}
+}
+
+func TestTodolistJSONIncludesDescriptionHTML(t *testing.T) {
+	body, err := json.Marshal([]Todolist{{ID: 1, Title: "Belanja", Description: "beli **susu**"}})
+	assert.NoError(t, err)
+
+	var decoded []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "Belanja", decoded[0]["title"])
+	assert.Equal(t, "<p>beli <strong>susu</strong></p>\n", decoded[0]["description_html"])
+}
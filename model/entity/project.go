@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type Project struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	UserID      int64     `gorm:"index" json:"-"`
+	WorkspaceID *int64    `json:"workspace_id"`
+	Name        string    `gorm:"type:varchar(100)" json:"name"`
+	Color       string    `gorm:"type:varchar(20)" json:"color"`
+	Archived    bool      `gorm:"default:false" json:"archived"`
+	SortOrder   int64     `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	AccessRole  string    `gorm:"-" json:"access_role,omitempty"`
+}
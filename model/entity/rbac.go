@@ -0,0 +1,24 @@
+package entity
+
+// role bawaan
+const (
+	RoleAdmin = "admin"
+)
+
+// permission yang dicek RequirePermission di router
+const (
+	PermissionTodosReadAll = "todos:read_all"
+	PermissionUsersRead    = "users:read"
+	PermissionRolesManage  = "roles:manage"
+	PermissionUsersManage  = "users:manage"
+)
+
+type Role struct {
+	ID   int64  `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"type:varchar(50)" json:"name"`
+}
+
+type Permission struct {
+	ID   int64  `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"type:varchar(100)" json:"name"`
+}
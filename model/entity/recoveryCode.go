@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RecoveryCode adalah kode cadangan 2FA sekali pakai, disimpan sebagai hash.
+type RecoveryCode struct {
+	ID        int64      `gorm:"primaryKey" json:"id"`
+	UserID    int64      `gorm:"index" json:"-"`
+	CodeHash  string     `gorm:"type:char(64)" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// RefreshToken disimpan sebagai hash. Setiap refresh membuat token baru di family yang sama,
+// token lama ditandai revoked; kalau token yang sudah revoked dipakai lagi, seluruh family dicabut.
+type RefreshToken struct {
+	ID        int64      `gorm:"primaryKey" json:"id"`
+	UserID    int64      `gorm:"index" json:"user_id"`
+	FamilyID  string     `gorm:"type:char(36)" json:"family_id"`
+	TokenHash string     `gorm:"type:char(64)" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
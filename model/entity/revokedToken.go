@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// RevokedToken adalah access token yang sudah logout, disimpan sampai token tersebut kadaluwarsa.
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey;type:char(36)" json:"jti"`
+	UserID    int64     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
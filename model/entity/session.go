@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Session dibuat setiap login. ID-nya dipakai sebagai claim sid di access token
+// dan sebagai family refresh token, jadi mencabut sesi juga mencabut refresh token-nya.
+type Session struct {
+	ID         string     `gorm:"primaryKey;type:char(36)" json:"id"`
+	UserID     int64      `gorm:"index" json:"-"`
+	UserAgent  string     `gorm:"type:varchar(255)" json:"user_agent"`
+	IP         string     `gorm:"column:ip;type:varchar(45)" json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"-"`
+	Current    bool       `gorm:"-" json:"current"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
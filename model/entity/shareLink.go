@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// ShareLink adalah link publik read-only ke sebuah todo. Token-nya tidak disimpan,
+// cukup dibuat ulang dari ID dan ExpiresAt karena ditandatangani.
+type ShareLink struct {
+	ID           int64      `gorm:"primaryKey" json:"id"`
+	TodoID       int64      `gorm:"index" json:"todo_id"`
+	UserID       int64      `json:"-"`
+	PasswordHash string     `gorm:"type:varchar(255)" json:"-"`
+	HasPassword  bool       `gorm:"-" json:"has_password"`
+	Token        string     `gorm:"-" json:"token"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (ShareLink) TableName() string {
+	return "share_links"
+}
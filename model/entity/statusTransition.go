@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type StatusTransition struct {
+	ID             int64     `gorm:"primaryKey" json:"id"`
+	TodoID         int64     `gorm:"index" json:"todo_id"`
+	FromStatus     string    `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus       string    `gorm:"type:varchar(20)" json:"to_status"`
+	TransitionedAt time.Time `gorm:"default:current_timestamp" json:"transitioned_at"`
+}
+
+func (StatusTransition) TableName() string {
+	return "todo_status_transitions"
+}
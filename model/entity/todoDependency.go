@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// TodoDependency berarti TodoID tidak bisa selesai sebelum BlockedByID selesai.
+type TodoDependency struct {
+	TodoID      int64     `gorm:"primaryKey" json:"todo_id"`
+	BlockedByID int64     `gorm:"primaryKey" json:"blocked_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (TodoDependency) TableName() string {
+	return "todo_dependencies"
+}
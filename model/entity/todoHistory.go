@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	HistoryCreated            = "created"
+	HistoryUpdated            = "updated"
+	HistoryStatusChanged      = "status_changed"
+	HistoryAttachmentUploaded = "attachment_uploaded"
+	HistoryAssigned           = "assigned"
+	HistoryDeleted            = "deleted"
+	HistoryRestored           = "restored"
+	HistoryProjectMoved       = "project_moved"
+	HistoryArchived           = "archived"
+	HistoryUnarchived         = "unarchived"
+	HistoryReordered          = "reordered"
+	HistoryLabelAttached      = "label_attached"
+	HistoryLabelDetached      = "label_detached"
+	HistoryChecklistAdded     = "checklist_added"
+	HistoryChecklistUpdated   = "checklist_updated"
+	HistoryChecklistDeleted   = "checklist_deleted"
+	HistoryDependencyAdded    = "dependency_added"
+	HistoryDependencyRemoved  = "dependency_removed"
+)
+
+// TodoHistory adalah catatan perubahan todo, hanya ditambah dan tidak pernah diubah.
+type TodoHistory struct {
+	ID        int64           `gorm:"primaryKey" json:"id"`
+	TodoID    int64           `gorm:"index" json:"todo_id"`
+	UserID    int64           `json:"user_id"`
+	Action    string          `gorm:"type:varchar(30)" json:"action"`
+	Before    json.RawMessage `gorm:"column:old_values;type:json" json:"before,omitempty"`
+	After     json.RawMessage `gorm:"column:new_values;type:json" json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (TodoHistory) TableName() string {
+	return "todo_history"
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	AccessOwner    = "owner"
+	AccessAssignee = "assignee"
+	// AccessManager untuk admin workspace: bisa mengubah dan menghapus todo,
+	// tapi share dan share link tetap hanya untuk pembuat todo atau owner workspace
+	AccessManager   = "manager"
+	ShareRoleViewer = "viewer"
+	ShareRoleEditor = "editor"
+)
+
+// TodoShare memberi user lain akses ke todo, viewer hanya bisa membaca
+// sedangkan editor juga bisa mengubah isi todo dan menambah attachment.
+type TodoShare struct {
+	TodoID    int64     `gorm:"primaryKey" json:"todo_id"`
+	UserID    int64     `gorm:"primaryKey" json:"user_id"`
+	Username  string    `gorm:"->" json:"username"`
+	Role      string    `gorm:"type:varchar(20)" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (TodoShare) TableName() string {
+	return "todo_shares"
+}
+
+// ProjectShare memberi user lain akses ke semua todo di dalam project
+// dengan role yang sama seperti TodoShare.
+type ProjectShare struct {
+	ProjectID int64     `gorm:"primaryKey" json:"project_id"`
+	UserID    int64     `gorm:"primaryKey" json:"user_id"`
+	Username  string    `gorm:"->" json:"username"`
+	Role      string    `gorm:"type:varchar(20)" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ProjectShare) TableName() string {
+	return "project_shares"
+}
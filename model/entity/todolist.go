@@ -1,11 +1,72 @@
 package entity
 
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"time"
+)
+
+// status workflow todo
+const (
+	StatusBacklog    = "backlog"
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusDone       = "done"
+	StatusCancelled  = "cancelled"
+)
+
+// Statuses berisi semua status yang dikenal, urut sesuai alur kerja.
+var Statuses = []string{StatusBacklog, StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}
+
+// prioritas P1 (paling penting) sampai P4 (default)
+const (
+	PriorityHighest = 1
+	PriorityLowest  = 4
+)
+
+// ClosedStatuses adalah status yang dianggap sudah selesai dikerjakan.
+var ClosedStatuses = []string{StatusDone, StatusCancelled}
+
 type Todolist struct {
-	ID          int64        `gorm:"primaryKey" json:"id"`
-	Title       string       `gorm:"type:varchar(300)" json:"title"`
-	Status      bool         `gorm:"default:false" json:"status"`
-	UserID      int64        `json:"-"`
-	Attachments []Attachment `gorm:"foreignKey:todo_id" json:"attachments"`
+	ID                int64              `gorm:"primaryKey" json:"id"`
+	Title             string             `gorm:"type:varchar(300)" json:"title"`
+	Description       string             `gorm:"type:text" json:"description"`
+	Status            string             `gorm:"type:varchar(20);default:todo" json:"status"`
+	StatusChangedAt   *time.Time         `json:"status_changed_at"`
+	StartAt           *time.Time         `json:"start_at"`
+	DueAt             *time.Time         `json:"due_at"`
+	Recurrence        string             `gorm:"type:varchar(255)" json:"recurrence"`
+	Priority          int                `gorm:"default:4" json:"priority"`
+	EstimateMinutes   *int               `json:"estimate_minutes"`
+	UserID            int64              `json:"-"`
+	OwnerUsername     string             `gorm:"-" json:"owner_username,omitempty"`
+	ProjectID         *int64             `json:"project_id"`
+	WorkspaceID       *int64             `json:"workspace_id"`
+	AssigneeID        *int64             `json:"assignee_id"`
+	AssigneeUsername  string             `gorm:"-" json:"assignee_username,omitempty"`
+	Position          string             `gorm:"type:varchar(255)" json:"position"`
+	ArchivedAt        *time.Time         `json:"archived_at"`
+	DeletedAt         gorm.DeletedAt     `gorm:"index" json:"deleted_at"`
+	Attachments       []Attachment       `gorm:"foreignKey:todo_id" json:"attachments"`
+	Labels            []Label            `gorm:"many2many:todolist_labels;" json:"labels"`
+	ChecklistItems    []ChecklistItem    `gorm:"foreignKey:todo_id" json:"checklist_items,omitempty"`
+	Progress          ChecklistProgress  `gorm:"-" json:"progress"`
+	Blocked           bool               `gorm:"-" json:"blocked"`
+	BlockedBy         []int64            `gorm:"-" json:"blocked_by"`
+	CommentCount      int64              `gorm:"-" json:"comment_count"`
+	AccessRole        string             `gorm:"-" json:"access_role,omitempty"`
+	StatusTransitions []StatusTransition `gorm:"foreignKey:todo_id" json:"status_transitions,omitempty"`
+}
+
+// MarshalJSON menambahkan description_html ke setiap response yang berisi todo,
+// jadi handler tidak perlu me-render deskripsi sendiri.
+func (t Todolist) MarshalJSON() ([]byte, error) {
+	type todolist Todolist
+	return json.Marshal(struct {
+		todolist
+		DescriptionHTML string `json:"description_html"`
+	}{todolist(t), RenderDescription(t.Description)})
 }
 
 //func (t Todolist) Read(p []byte) (n int, err error) {
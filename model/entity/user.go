@@ -1,7 +1,18 @@
 package entity
 
+import "time"
+
 type User struct {
 	Id       int64  `json:"id"`
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// AutoArchiveDays: todo yang done lebih dari N hari otomatis diarsipkan, 0 berarti nonaktif
+	AutoArchiveDays int `json:"auto_archive_days"`
+	// DisabledAt diisi admin saat akun dinonaktifkan, user tidak bisa login maupun memakai token lama
+	DisabledAt *time.Time `json:"-"`
+	// PasswordResetRequired: user harus mengganti password lewat /password-reset sebelum bisa login lagi
+	PasswordResetRequired bool `json:"-"`
+	// TOTPSecret diisi saat enroll 2FA, 2FA baru aktif setelah dikonfirmasi (TOTPEnabledAt terisi)
+	TOTPSecret    *string    `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at" json:"-"`
 }
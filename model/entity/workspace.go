@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// role anggota workspace, dari yang paling tinggi
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleGuest  = "guest"
+)
+
+type Workspace struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(100)" json:"name"`
+	OwnerID   int64     `json:"owner_id"`
+	Role      string    `gorm:"->" json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int64     `gorm:"primaryKey" json:"workspace_id"`
+	UserID      int64     `gorm:"primaryKey" json:"user_id"`
+	Username    string    `gorm:"->" json:"username"`
+	Role        string    `gorm:"type:varchar(20)" json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WorkspaceInvitation adalah undangan yang harus di-accept dulu sebelum user jadi anggota.
+type WorkspaceInvitation struct {
+	ID            int64      `gorm:"primaryKey" json:"id"`
+	WorkspaceID   int64      `json:"workspace_id"`
+	WorkspaceName string     `gorm:"->" json:"workspace_name,omitempty"`
+	UserID        int64      `json:"user_id"`
+	Role          string     `gorm:"type:varchar(20)" json:"role"`
+	InvitedBy     int64      `json:"invited_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	AcceptedAt    *time.Time `json:"accepted_at"`
+}
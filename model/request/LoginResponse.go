@@ -1,8 +1,21 @@
 package request
 
 type LoginResponse struct {
-	Message string `json:"message"`
-	Token   string `json:"token"`
-	UserID  int    `json:"user_id"`
+	Message      string `json:"message"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	UserID       int    `json:"user_id"`
 	//Todolist []entity.Todolist `json:"todolist"`
 }
+
+// TokenResponse dikembalikan /token/refresh, refresh_token lama tidak berlaku lagi.
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
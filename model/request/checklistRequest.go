@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+type ChecklistItemCreateRequest struct {
+	Title string `json:"title" binding:"required,max=300"`
+}
+
+type ChecklistItemUpdateRequest struct {
+	Title     string `json:"title" binding:"omitempty,max=300"`
+	Done      *bool  `json:"done"`
+	ItemOrder *int64 `json:"item_order" binding:"omitempty,min=1"`
+}
+
+func (r *ChecklistItemUpdateRequest) ReqChecklistItem() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if r.Title != "" {
+		updates["title"] = r.Title
+	}
+	if r.Done != nil {
+		updates["done"] = *r.Done
+		if *r.Done {
+			updates["completed_at"] = time.Now()
+		} else {
+			updates["completed_at"] = nil
+		}
+	}
+	if r.ItemOrder != nil {
+		updates["item_order"] = *r.ItemOrder
+	}
+	return updates
+}
@@ -0,0 +1,5 @@
+package request
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
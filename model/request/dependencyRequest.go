@@ -0,0 +1,5 @@
+package request
+
+type DependencyCreateRequest struct {
+	BlockedByID int64 `json:"blocked_by_id" binding:"required"`
+}
@@ -0,0 +1,22 @@
+package request
+
+type LabelCreateRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"omitempty,max=20"`
+}
+
+type LabelUpdateRequest struct {
+	Name  string `json:"name" binding:"omitempty,max=50"`
+	Color string `json:"color" binding:"omitempty,max=20"`
+}
+
+func (r *LabelUpdateRequest) ReqLabel() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if r.Name != "" {
+		updates["name"] = r.Name
+	}
+	if r.Color != "" {
+		updates["color"] = r.Color
+	}
+	return updates
+}
@@ -0,0 +1,10 @@
+package request
+
+// PasswordResetRequest mengganti password dengan password lama (atau password sementara dari admin).
+type PasswordResetRequest struct {
+	Username    string `json:"username" binding:"required"`
+	Password    string `json:"password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=72"`
+	// Code wajib diisi (kode TOTP atau recovery code) kalau 2FA aktif
+	Code string `json:"code"`
+}
@@ -0,0 +1,36 @@
+package request
+
+type ProjectCreateRequest struct {
+	Name      string `json:"name" binding:"required,max=100"`
+	Color     string `json:"color" binding:"omitempty,max=20"`
+	SortOrder int64  `json:"sort_order"`
+}
+
+type ProjectUpdateRequest struct {
+	Name      string `json:"name" binding:"omitempty,max=100"`
+	Color     string `json:"color" binding:"omitempty,max=20"`
+	Archived  *bool  `json:"archived"`
+	SortOrder *int64 `json:"sort_order"`
+}
+
+func (r *ProjectUpdateRequest) ReqProject() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if r.Name != "" {
+		updates["name"] = r.Name
+	}
+	if r.Color != "" {
+		updates["color"] = r.Color
+	}
+	if r.Archived != nil {
+		updates["archived"] = *r.Archived
+	}
+	if r.SortOrder != nil {
+		updates["sort_order"] = *r.SortOrder
+	}
+	return updates
+}
+
+// TodoMoveRequest memindahkan todo ke project lain, project_id null berarti tanpa project.
+type TodoMoveRequest struct {
+	ProjectID *int64 `json:"project_id"`
+}
@@ -7,3 +7,12 @@ type SearchResponse struct {
 	Data   []entity.Todolist `json:"data"`
 	Total  int64             `json:"total"`
 }
+
+// PageResponse sama seperti SearchResponse tapi untuk data selain todo.
+type PageResponse struct {
+	Status  int         `json:"status"`
+	Data    interface{} `json:"data"`
+	Total   int64       `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type AutoArchiveRequest struct {
+	Days *int `json:"days" binding:"required,min=0,max=3650"`
+}
@@ -0,0 +1,11 @@
+package request
+
+type TodoShareRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=viewer editor"`
+}
+
+type ShareLinkCreateRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" binding:"required,min=1,max=8760"`
+	Password       string `json:"password" binding:"omitempty,min=4,max=72"`
+}
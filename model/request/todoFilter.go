@@ -0,0 +1,25 @@
+package request
+
+const (
+	LabelMatchAny = "any"
+	LabelMatchAll = "all"
+)
+
+// TodoSortFields adalah kolom yang boleh dipakai di parameter sort.
+var TodoSortFields = []string{"priority", "due_at", "start_at", "estimate_minutes", "title", "status", "position", "id"}
+
+// SortField adalah satu kunci pengurutan, contoh "-due_at" berarti due_at descending.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// TodoFilter berisi filter opsional untuk list dan search todo.
+type TodoFilter struct {
+	LabelIDs   []int64
+	LabelMatch string
+	ProjectID  *int64
+	Sort       []SortField
+	// IncludeArchived ikut menampilkan todo yang sudah diarsipkan
+	IncludeArchived bool
+}
@@ -1,6 +1,9 @@
 package request
 
-import "todoGin/model/entity"
+import (
+	"time"
+	"todoGin/model/entity"
+)
 
 type TodoResponse struct {
 	Status  interface{}     `json:"status"`
@@ -30,3 +33,26 @@ type TodoUpdateResponse struct {
 	Message string      `json:"data"`
 	Todos   interface{} `json:"todos"`
 }
+
+type SmartListResponse struct {
+	Message  string            `json:"message"`
+	List     string            `json:"list"`
+	TimeZone string            `json:"time_zone"`
+	Data     int               `json:"data"`
+	Todos    []entity.Todolist `json:"todos"`
+}
+
+// PublicTodo adalah tampilan read-only todo untuk public share link,
+// tanpa data milik user seperti label, assignee atau komentar.
+type PublicTodo struct {
+	Title           string                   `json:"title"`
+	Description     string                   `json:"description"`
+	DescriptionHTML string                   `json:"description_html"`
+	Status          string                   `json:"status"`
+	StartAt         *time.Time               `json:"start_at"`
+	DueAt           *time.Time               `json:"due_at"`
+	Priority        int                      `json:"priority"`
+	Attachments     []entity.Attachment      `json:"attachments"`
+	ChecklistItems  []entity.ChecklistItem   `json:"checklist_items"`
+	Progress        entity.ChecklistProgress `json:"progress"`
+}
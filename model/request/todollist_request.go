@@ -1,13 +1,32 @@
 package request
 
+import "time"
+
 type TodolistCreateRequest struct {
-	Title  string `json:"title" binding:"required,min=2"`
-	UserID int64  `json:"user_id"`
+	Title           string     `json:"title" binding:"required,min=2"`
+	Description     string     `json:"description" binding:"max=10000"`
+	Status          string     `json:"status" binding:"omitempty,oneof=backlog todo in_progress blocked done cancelled"`
+	StartAt         *time.Time `json:"start_at"`
+	DueAt           *time.Time `json:"due_at"`
+	Recurrence      string     `json:"recurrence" binding:"omitempty,max=255"`
+	Priority        int        `json:"priority" binding:"omitempty,min=1,max=4"`
+	EstimateMinutes *int       `json:"estimate_minutes" binding:"omitempty,min=0,max=100000"`
+	ProjectID       *int64     `json:"project_id"`
+	UserID          int64      `json:"user_id"`
 }
 
 type TodolistUpdateRequest struct {
-	Title  string `json:"title"`
-	Status bool   `json:"status"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description" binding:"omitempty,max=10000"`
+	Status          *string    `json:"status" binding:"omitempty,oneof=backlog todo in_progress blocked done cancelled"`
+	StartAt         *time.Time `json:"start_at"`
+	DueAt           *time.Time `json:"due_at"`
+	ClearStartAt    bool       `json:"clear_start_at"`
+	ClearDueAt      bool       `json:"clear_due_at"`
+	Recurrence      *string    `json:"recurrence" binding:"omitempty,max=255"`
+	Priority        *int       `json:"priority" binding:"omitempty,min=1,max=4"`
+	EstimateMinutes *int       `json:"estimate_minutes" binding:"omitempty,min=0,max=100000"`
+	ClearEstimate   bool       `json:"clear_estimate"`
 }
 
 func (r *TodolistUpdateRequest) ReqTodo() map[string]interface{} {
@@ -15,7 +34,36 @@ func (r *TodolistUpdateRequest) ReqTodo() map[string]interface{} {
 	if r.Title != "" {
 		updates["title"] = r.Title
 	}
-	updates["status"] = r.Status
+	if r.Description != nil {
+		updates["description"] = *r.Description
+	}
+	// status tidak diubah di sini, perpindahan status lewat workflow di service
+
+	// tanggal hanya diubah kalau dikirim, clear_* untuk menghapusnya
+	if r.StartAt != nil {
+		updates["start_at"] = *r.StartAt
+	} else if r.ClearStartAt {
+		updates["start_at"] = nil
+	}
+	if r.DueAt != nil {
+		updates["due_at"] = *r.DueAt
+	} else if r.ClearDueAt {
+		updates["due_at"] = nil
+	}
+
+	if r.Priority != nil {
+		updates["priority"] = *r.Priority
+	}
+	if r.EstimateMinutes != nil {
+		updates["estimate_minutes"] = *r.EstimateMinutes
+	} else if r.ClearEstimate {
+		updates["estimate_minutes"] = nil
+	}
+
+	// recurrence kosong berarti berhenti berulang
+	if r.Recurrence != nil {
+		updates["recurrence"] = *r.Recurrence
+	}
 
 	//if r.Status != "" {
 	//	updates["status"] = r.Status
@@ -28,3 +76,14 @@ func (r *TodolistUpdateRequest) ReqTodo() map[string]interface{} {
 //type TodolistStatusRequest struct {
 //	Status bool `gorm:"default:false" json:"status"`
 //}
+
+// TodoReorderRequest memindahkan todo sebelum before_id atau sesudah after_id.
+type TodoReorderRequest struct {
+	BeforeID *int64 `json:"before_id"`
+	AfterID  *int64 `json:"after_id"`
+}
+
+// TodoAssignRequest mengganti assignee todo, assignee_id null berarti tidak di-assign.
+type TodoAssignRequest struct {
+	AssigneeID *int64 `json:"assignee_id"`
+}
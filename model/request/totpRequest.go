@@ -0,0 +1,32 @@
+package request
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFALoginRequest adalah langkah kedua login, code boleh kode TOTP atau recovery code.
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// TOTPEnrollResponse berisi secret untuk authenticator app, qr_png adalah PNG dalam base64.
+type TOTPEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+	QRPNG  string `json:"qr_png"`
+}
+
+type TOTPStatusResponse struct {
+	Enabled           bool  `json:"enabled"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+// MFARequiredResponse dikembalikan /login untuk user dengan 2FA aktif,
+// mfa_token ditukar dengan token asli lewat /login/2fa.
+type MFARequiredResponse struct {
+	Message     string `json:"message"`
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
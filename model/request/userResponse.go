@@ -0,0 +1,10 @@
+package request
+
+// UserResponse adalah data user untuk route admin, tanpa password.
+type UserResponse struct {
+	ID                    int64    `json:"id"`
+	Username              string   `json:"username"`
+	Roles                 []string `json:"roles"`
+	Disabled              bool     `json:"disabled"`
+	PasswordResetRequired bool     `json:"password_reset_required"`
+}
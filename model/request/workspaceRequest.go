@@ -0,0 +1,15 @@
+package request
+
+type WorkspaceCreateRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// owner tidak bisa diundang atau diberikan lewat role, hanya pembuat workspace
+type WorkspaceInviteRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=admin member guest"`
+}
+
+type WorkspaceMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member guest"`
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "errors"
+
+// ErrConflict dikembalikan kalau data sudah berubah oleh request lain
+// sehingga operasi tidak bisa diterapkan.
+var ErrConflict = errors.New("conflicting update")
+
+// ErrLastAdmin dikembalikan kalau operasi akan menghapus role admin terakhir.
+var ErrLastAdmin = errors.New("cannot remove the last admin")
+
+// ErrDependencyCycle dikembalikan kalau relasi blocked-by baru akan membuat siklus.
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
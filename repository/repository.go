@@ -3,17 +3,32 @@ package repository
 import (
 	"io"
 	"mime/multipart"
+	"time"
 	"todoGin/model/entity"
+	"todoGin/model/request"
 )
 
 type TodoRepository interface {
-	GetAll() ([]entity.Todolist, error)
-	GetAllUserByID(UserID int64) ([]entity.Todolist, error)
+	GetAll(page, perPage int) ([]entity.Todolist, int64, error)
+	GetAllUserByID(UserID int64, filter request.TodoFilter) ([]entity.Todolist, error)
 	GetByID(todoID, userID int64) (*entity.Todolist, error)
-	Create(title string, userID int64) (*entity.Todolist, error)
+	Create(todo *entity.Todolist) (*entity.Todolist, error)
 	Update(todoID, userID int64, updates map[string]interface{}) (*entity.Todolist, error)
-	UpdatetoAtch(todo *entity.Todolist) error
+	ChangeStatus(todoID int64, from, to string, updates map[string]interface{}, next *entity.Todolist) (*entity.StatusTransition, error)
+	MoveTodo(todoID, userID, targetID int64, after bool) (string, error)
+	UpdatetoAtch(attachment *entity.Attachment) error
 	Delete(todoID, userID int64) (int64, error)
+	GetTrashByUser(userID int64) ([]entity.Todolist, error)
+	GetTrashedTodo(todoID, userID int64) (*entity.Todolist, error)
+	RestoreTodo(todoID, userID int64) (int64, error)
+	PurgeTodo(todoID, userID int64) (int64, error)
+	PurgeTrash(before time.Time) (int64, error)
+	GetArchivedByUser(userID int64, page, perPage int) ([]entity.Todolist, int64, error)
+	SetArchived(todoID, userID int64, archived bool) (int64, error)
+	UpdateAutoArchiveDays(userID int64, days int) error
+	ArchiveDoneTodos(now time.Time) (int64, error)
+	GetDueBetween(userID int64, from *time.Time, to time.Time) ([]entity.Todolist, error)
+	GetWithoutDueDate(userID int64) ([]entity.Todolist, error)
 	CreateUser(user *entity.User) error
 	GetUserByUsername(username string) (*entity.User, error)
 	//UploadTodoFileS3(file *multipart.FileHeader, url string) error
@@ -24,5 +39,101 @@ type TodoRepository interface {
 	UpdateTodoWithAttachments(todo *entity.Todolist) error
 	UploadFileS3Buckets(file io.Reader, fileName string) (*string, error)
 	UploadTodoFileLocalAtch(file *multipart.FileHeader, todoID, userID int64) (*entity.Attachment, error)
-	SearchTodolistByUser(userID int64, search string, page, perPage int) ([]entity.Todolist, int64, error)
+	SearchTodolistByUser(userID int64, search string, page, perPage int, filter request.TodoFilter) ([]entity.Todolist, int64, error)
+	/////////////////////
+	GetLabelsByUser(userID int64) ([]entity.Label, error)
+	GetLabelByID(labelID, userID int64) (*entity.Label, error)
+	GetLabelByName(userID int64, name string) (*entity.Label, error)
+	CreateLabel(label *entity.Label) error
+	UpdateLabel(labelID, userID int64, updates map[string]interface{}) error
+	DeleteLabel(labelID, userID int64) (int64, error)
+	AttachLabel(todoID, labelID int64) error
+	DetachLabel(todoID, labelID int64) (int64, error)
+	/////////////////////
+	CreateChecklistItem(item *entity.ChecklistItem) error
+	GetChecklistItem(itemID, todoID int64) (*entity.ChecklistItem, error)
+	UpdateChecklistItem(itemID, todoID int64, updates map[string]interface{}) error
+	DeleteChecklistItem(itemID, todoID int64) (int64, error)
+	/////////////////////
+	GetProjectsByUser(userID int64, includeArchived bool) ([]entity.Project, error)
+	GetProjectByID(projectID, userID int64) (*entity.Project, error)
+	CreateProject(project *entity.Project) error
+	UpdateProject(projectID, userID int64, updates map[string]interface{}) error
+	DeleteProject(projectID, userID int64) (int64, error)
+	/////////////////////
+	AddDependency(todoID, blockedByID, userID int64) error
+	RemoveDependency(todoID, blockedByID int64) (int64, error)
+	/////////////////////
+	AddHistory(history *entity.TodoHistory) error
+	GetHistory(todoID int64, page, perPage int) ([]entity.TodoHistory, int64, error)
+	/////////////////////
+	GetComments(todoID int64, page, perPage int) ([]entity.Comment, int64, error)
+	GetComment(commentID, todoID int64) (*entity.Comment, error)
+	CreateComment(comment *entity.Comment) error
+	UpdateComment(commentID int64, body string) error
+	DeleteComment(commentID int64) (int64, error)
+	/////////////////////
+	ShareTodo(share *entity.TodoShare) error
+	GetTodoShares(todoID int64) ([]entity.TodoShare, error)
+	RemoveTodoShare(todoID, userID int64) (int64, error)
+	GetSharedWithUser(userID int64) ([]entity.Todolist, error)
+	ShareProject(share *entity.ProjectShare) error
+	GetProjectShares(projectID int64) ([]entity.ProjectShare, error)
+	RemoveProjectShare(projectID, userID int64) (int64, error)
+	GetProjectsSharedWithUser(userID int64) ([]entity.Project, error)
+	/////////////////////
+	GetUserByID(userID int64) (*entity.User, error)
+	IsAssignable(todo *entity.Todolist, userID int64) (bool, error)
+	AssignTodo(todoID int64, assigneeID *int64) error
+	GetAssignedToUser(userID int64) ([]entity.Todolist, error)
+	/////////////////////
+	CreateShareLink(link *entity.ShareLink) error
+	GetShareLink(linkID int64) (*entity.ShareLink, error)
+	GetActiveShareLinks(userID int64, now time.Time) ([]entity.ShareLink, error)
+	RevokeShareLink(linkID, userID int64) (int64, error)
+	GetPublicTodo(todoID int64) (*entity.Todolist, error)
+	/////////////////////
+	InWorkspace(workspaceID int64, role string) TodoRepository
+	CreateWorkspace(workspace *entity.Workspace) error
+	GetWorkspacesByUser(userID int64) ([]entity.Workspace, error)
+	GetWorkspaceMember(workspaceID, userID int64) (*entity.WorkspaceMember, error)
+	GetWorkspaceMembers(workspaceID int64) ([]entity.WorkspaceMember, error)
+	UpdateWorkspaceMemberRole(workspaceID, userID int64, role string) (int64, error)
+	RemoveWorkspaceMember(workspaceID, userID int64) (int64, error)
+	CreateWorkspaceInvitation(invitation *entity.WorkspaceInvitation) error
+	GetPendingInvitations(userID int64) ([]entity.WorkspaceInvitation, error)
+	AcceptWorkspaceInvitation(invitationID, userID int64) (*entity.WorkspaceMember, error)
+	/////////////////////
+	HasPermission(userID int64, permission string) (bool, error)
+	GetRolesByUsers(userIDs []int64) (map[int64][]string, error)
+	AssignRole(userID int64, roleName string) (bool, error)
+	RemoveRole(userID int64, roleName string) (int64, error)
+	GetUsers(query string, page, perPage int) ([]entity.User, int64, error)
+	SetUserDisabled(userID int64, disabled bool) (int64, error)
+	UpdatePassword(userID int64, hashedPassword string, resetRequired bool) (int64, error)
+	DeleteUser(userID int64) (int64, error)
+	/////////////////////
+	CreateRefreshToken(token *entity.RefreshToken) error
+	GetRefreshToken(tokenHash string) (*entity.RefreshToken, error)
+	RotateRefreshToken(oldID int64, next *entity.RefreshToken) (bool, error)
+	DeleteExpiredRefreshTokens(before time.Time) (int64, error)
+	RevokeToken(token *entity.RevokedToken) error
+	GetRevokedToken(jti string) (*entity.RevokedToken, error)
+	DeleteExpiredRevokedTokens(before time.Time) (int64, error)
+	/////////////////////
+	CreateSession(session *entity.Session) error
+	TouchSession(sessionID string, now time.Time) (bool, error)
+	GetActiveSessions(userID int64) ([]entity.Session, error)
+	RevokeSession(sessionID string, userID int64) (int64, error)
+	RevokeUserSessions(userID int64, exceptSessionID string) (int64, error)
+	DeleteStaleSessions(idleBefore, revokedBefore time.Time) (int64, error)
+	/////////////////////
+	SetTOTPSecret(userID int64, secret string) error
+	EnableTOTP(userID int64, codeHashes []string) error
+	DisableTOTP(userID int64) error
+	UseRecoveryCode(userID int64, codeHash string) (bool, error)
+	CountRecoveryCodes(userID int64) (int64, error)
+	StartMFAAttempt(userID int64, now time.Time, maxAttempts int, lockUntil time.Time) (bool, error)
+	ResetMFAFailures(userID int64) error
+	AcceptTOTPStep(userID, step int64) (bool, error)
 }
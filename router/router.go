@@ -3,6 +3,7 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"todoGin/middleware"
+	"todoGin/model/entity"
 	todoservice "todoGin/service"
 )
 
@@ -20,23 +21,115 @@ func (rb *RouteBuilder) RouteInit() *gin.Engine {
 	r.Use(middleware.RecoveryMiddleware(), middleware.Logger())
 	//r.Use(gin.Recovery(), middleware.Logger(), middleware.BasicAuth())
 
-	auth := r.Group("/", middleware.Authmiddleware())
+	// route akun dan workspace tidak dibatasi header X-Workspace-ID
+	account := r.Group("/", middleware.Authmiddleware(rb.todoService.Accounts, rb.todoService.Denylist, rb.todoService.Sessions))
+	{
+		account.POST("/logout", rb.todoService.LogoutHandler)
+		account.GET("/sessions", rb.todoService.SessionHandlerGetAll)
+		account.DELETE("/sessions", rb.todoService.SessionHandlerRevokeOthers)
+		account.DELETE("/sessions/:id", rb.todoService.SessionHandlerRevoke)
+		account.GET("/2fa", rb.todoService.TOTPHandlerStatus)
+		account.POST("/2fa/enroll", rb.todoService.TOTPHandlerEnroll)
+		account.POST("/2fa/confirm", rb.todoService.TOTPHandlerConfirm)
+		account.POST("/2fa/disable", rb.todoService.TOTPHandlerDisable)
+		account.GET("/workspaces", rb.todoService.WorkspaceHandlerGetAll)
+		account.POST("/workspaces", rb.todoService.WorkspaceHandlerCreate)
+		account.GET("/workspaces/:id/members", rb.todoService.WorkspaceMemberHandlerGetAll)
+		account.PUT("/workspaces/:id/members/:user_id", rb.todoService.WorkspaceMemberHandlerUpdate)
+		account.DELETE("/workspaces/:id/members/:user_id", rb.todoService.WorkspaceMemberHandlerDelete)
+		account.POST("/workspaces/:id/invitations", rb.todoService.WorkspaceInviteHandler)
+		account.GET("/workspace-invitations", rb.todoService.InvitationHandlerGetAll)
+		account.POST("/workspace-invitations/:id/accept", rb.todoService.InvitationHandlerAccept)
+	}
+
+	// route admin, setiap route memeriksa permission dari role user
+	admin := r.Group("/admin", middleware.Authmiddleware(rb.todoService.Accounts, rb.todoService.Denylist, rb.todoService.Sessions))
+	{
+		perms := rb.todoService.TodoRepository
+		admin.GET("/todos", middleware.RequirePermission(perms, entity.PermissionTodosReadAll), rb.todoService.AdminTodoHandlerGetAll)
+		admin.GET("/users", middleware.RequirePermission(perms, entity.PermissionUsersRead), rb.todoService.AdminUserHandlerGetAll)
+		admin.POST("/users/:id/disable", middleware.RequirePermission(perms, entity.PermissionUsersManage), rb.todoService.AdminUserHandlerDisable)
+		admin.POST("/users/:id/enable", middleware.RequirePermission(perms, entity.PermissionUsersManage), rb.todoService.AdminUserHandlerEnable)
+		admin.POST("/users/:id/password-reset", middleware.RequirePermission(perms, entity.PermissionUsersManage), rb.todoService.AdminUserHandlerResetPassword)
+		admin.DELETE("/users/:id", middleware.RequirePermission(perms, entity.PermissionUsersManage), rb.todoService.AdminUserHandlerDelete)
+		admin.POST("/users/:id/roles/:role", middleware.RequirePermission(perms, entity.PermissionRolesManage), rb.todoService.AdminRoleHandlerAssign)
+		admin.DELETE("/users/:id/roles/:role", middleware.RequirePermission(perms, entity.PermissionRolesManage), rb.todoService.AdminRoleHandlerRemove)
+	}
+
+	auth := r.Group("/", middleware.Authmiddleware(rb.todoService.Accounts, rb.todoService.Denylist, rb.todoService.Sessions), rb.todoService.WorkspaceScope())
 	{
 		auth.GET("/manage-todos", rb.todoService.TodolistHandlerGetAll)
+		auth.GET("/manage-todos/smart/:list", rb.todoService.TodolistSmartListHandler)
+		auth.GET("/manage-todos/archived", rb.todoService.TodolistArchivedHandler)
 		auth.GET("/access", rb.todoService.Access)
 		auth.POST("/manage-todo", rb.todoService.TodolistHandlerCreate)
 		auth.GET("/manage-todo/todo/:id", rb.todoService.TodolistHandlerGetByID)
 		auth.PUT("/manage-todo/todo/:id", rb.todoService.TodolistHandlerUpdate)
 		auth.DELETE("/manage-todo/todo/:id", rb.todoService.TodolistHandlerDelete)
+		auth.GET("/manage-todo/todo/:id/occurrences", rb.todoService.TodolistOccurrencesHandler)
+		auth.PUT("/manage-todo/todo/:id/position", rb.todoService.TodolistReorderHandler)
+		auth.POST("/manage-todo/todo/:id/archive", rb.todoService.TodolistArchiveHandler)
+		auth.DELETE("/manage-todo/todo/:id/archive", rb.todoService.TodolistUnarchiveHandler)
+		auth.PUT("/settings/auto-archive", rb.todoService.AutoArchiveSettingHandler)
 		//auth.POST("/manage-todo/uploadS3", rb.todoService.TodoHandlerUploadFileS3)
 		//auth.POST("/manage-todo/uploadLocal", rb.todoService.TodoHandlerUploadFileLocal)
 		auth.POST("/uploadS3/:id", rb.todoService.UploadTodoFileS3AtchHandler)
 		auth.POST("/uploadLocal/:id", rb.todoService.UploadTodoLocalAtchHandler)
 		auth.GET("/list-Search", rb.todoService.TodolistsSearchHandler)
+
+		// label selalu milik pribadi user, jadi tidak bisa dipakai di dalam workspace
+		personal := rb.todoService.PersonalOnly()
+		auth.GET("/labels", personal, rb.todoService.LabelHandlerGetAll)
+		auth.POST("/labels", personal, rb.todoService.LabelHandlerCreate)
+		auth.PUT("/labels/:id", personal, rb.todoService.LabelHandlerUpdate)
+		auth.DELETE("/labels/:id", personal, rb.todoService.LabelHandlerDelete)
+		auth.POST("/manage-todo/todo/:id/labels/:label_id", personal, rb.todoService.TodoLabelHandlerAttach)
+		auth.DELETE("/manage-todo/todo/:id/labels/:label_id", personal, rb.todoService.TodoLabelHandlerDetach)
+		auth.POST("/manage-todo/todo/:id/dependencies", rb.todoService.DependencyHandlerCreate)
+		auth.DELETE("/manage-todo/todo/:id/dependencies/:blocker_id", rb.todoService.DependencyHandlerDelete)
+		auth.GET("/manage-todo/todo/:id/history", rb.todoService.TodoHistoryHandler)
+		auth.GET("/manage-todo/todo/:id/comments", rb.todoService.CommentHandlerGetAll)
+		auth.POST("/manage-todo/todo/:id/comments", rb.todoService.CommentHandlerCreate)
+		auth.PUT("/manage-todo/todo/:id/comments/:comment_id", rb.todoService.CommentHandlerUpdate)
+		auth.DELETE("/manage-todo/todo/:id/comments/:comment_id", rb.todoService.CommentHandlerDelete)
+		auth.GET("/manage-todo/todo/:id/shares", rb.todoService.TodoShareHandlerGetAll)
+		auth.POST("/manage-todo/todo/:id/shares", rb.todoService.TodoShareHandlerCreate)
+		auth.DELETE("/manage-todo/todo/:id/shares/:user_id", rb.todoService.TodoShareHandlerDelete)
+		auth.GET("/shared-with-me", rb.todoService.SharedWithMeHandler)
+		auth.GET("/shared-with-me/projects", rb.todoService.SharedProjectsWithMeHandler)
+		auth.PUT("/manage-todo/todo/:id/assignee", rb.todoService.TodoAssignHandler)
+		auth.GET("/assigned-to-me", rb.todoService.AssignedToMeHandler)
+		auth.POST("/manage-todo/todo/:id/share-links", rb.todoService.ShareLinkHandlerCreate)
+		auth.GET("/share-links", rb.todoService.ShareLinkHandlerGetAll)
+		auth.DELETE("/share-links/:id", rb.todoService.ShareLinkHandlerRevoke)
+
+		auth.POST("/manage-todo/todo/:id/checklist", rb.todoService.ChecklistHandlerCreate)
+		auth.PUT("/manage-todo/todo/:id/checklist/:item_id", rb.todoService.ChecklistHandlerUpdate)
+		auth.DELETE("/manage-todo/todo/:id/checklist/:item_id", rb.todoService.ChecklistHandlerDelete)
+
+		auth.GET("/projects", rb.todoService.ProjectHandlerGetAll)
+		auth.POST("/projects", rb.todoService.ProjectHandlerCreate)
+		auth.GET("/projects/:id", rb.todoService.ProjectHandlerGetByID)
+		auth.PUT("/projects/:id", rb.todoService.ProjectHandlerUpdate)
+		auth.DELETE("/projects/:id", rb.todoService.ProjectHandlerDelete)
+		auth.GET("/projects/:id/shares", rb.todoService.ProjectShareHandlerGetAll)
+		auth.POST("/projects/:id/shares", rb.todoService.ProjectShareHandlerCreate)
+		auth.DELETE("/projects/:id/shares/:user_id", rb.todoService.ProjectShareHandlerDelete)
+		auth.PUT("/manage-todo/todo/:id/project", rb.todoService.TodoMoveHandler)
+
+		auth.GET("/trash", rb.todoService.TrashHandlerGetAll)
+		auth.POST("/trash/:id/restore", rb.todoService.TrashHandlerRestore)
+		auth.DELETE("/trash/:id", rb.todoService.TrashHandlerPurge)
 	}
 
 	r.POST("/uploadBuckets", rb.todoService.UploadFileS3BucketsHandler)
 	r.POST("/register", rb.todoService.Register)
 	r.POST("/login", rb.todoService.Login)
+	r.POST("/login/2fa", rb.todoService.LoginMFAHandler)
+	r.POST("/password-reset", rb.todoService.ResetPassword)
+	r.POST("/token/refresh", rb.todoService.RefreshTokenHandler)
+	r.GET("/.well-known/jwks.json", rb.todoService.JWKSHandler)
+	// share link publik, bisa dibuka tanpa login
+	r.GET("/public/todos/:token", rb.todoService.PublicTodoHandler)
 	return r
 }
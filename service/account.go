@@ -0,0 +1,82 @@
+package service
+
+import (
+	"sync"
+	"time"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// AccountCache menyimpan status akun (dinonaktifkan, harus reset password, atau sudah dihapus)
+// untuk Authmiddleware selama cacheTTL supaya tidak query tabel users di setiap request.
+// Handler yang mengubah status akun memanggil Forget, instance lain paling lambat ikut
+// berubah setelah cacheTTL.
+type AccountCache struct {
+	repo     repository.TodoRepository
+	cacheTTL time.Duration
+
+	mu       sync.RWMutex
+	accounts map[int64]cachedAccount
+}
+
+type cachedAccount struct {
+	user      *entity.User // nil kalau user sudah dihapus
+	recheckAt time.Time
+}
+
+func NewAccountCache(repo repository.TodoRepository, cacheTTL time.Duration) *AccountCache {
+	return &AccountCache{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		accounts: make(map[int64]cachedAccount),
+	}
+}
+
+// GetUserByID hanya mengisi field yang dipakai untuk cek status akun.
+func (c *AccountCache) GetUserByID(userID int64) (*entity.User, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	cached, ok := c.accounts[userID]
+	c.mu.RUnlock()
+	if ok && now.Before(cached.recheckAt) {
+		return cached.user, nil
+	}
+
+	user, err := c.repo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *entity.User
+	if user != nil {
+		status = &entity.User{
+			Id:                    user.Id,
+			DisabledAt:            user.DisabledAt,
+			PasswordResetRequired: user.PasswordResetRequired,
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[userID] = cachedAccount{user: status, recheckAt: now.Add(c.cacheTTL)}
+	return status, nil
+}
+
+// Forget dipanggil setelah status akun berubah supaya request berikutnya membaca database.
+func (c *AccountCache) Forget(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accounts, userID)
+}
+
+// Prune membuang isi cache yang sudah kadaluwarsa.
+func (c *AccountCache) Prune(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for userID, cached := range c.accounts {
+		if cached.recheckAt.Before(now) {
+			delete(c.accounts, userID)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func TestAccountCacheReadsOncePerTTL(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetUserByID", int64(3)).Return(&entity.User{Id: 3, Username: "sari", Password: "hash"}, nil).Once()
+	cache := NewAccountCache(repo, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		user, err := cache.GetUserByID(3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), user.Id)
+		// hanya status akun yang disimpan, bukan hash password
+		assert.Empty(t, user.Password)
+	}
+
+	// user yang sudah dihapus juga di-cache supaya token lamanya tidak memicu query terus
+	repo.On("GetUserByID", int64(4)).Return(nil, nil).Once()
+	for i := 0; i < 2; i++ {
+		user, err := cache.GetUserByID(4)
+		require.NoError(t, err)
+		assert.Nil(t, user)
+	}
+}
+
+func TestAdminDisableForgetsCachedAccount(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	handler := NewTodoService(repo)
+
+	repo.On("GetUserByID", int64(3)).Return(&entity.User{Id: 3}, nil).Once()
+	user, err := handler.Accounts.GetUserByID(3)
+	require.NoError(t, err)
+	assert.Nil(t, user.DisabledAt)
+
+	repo.On("GetUserByID", int64(3)).Return(&entity.User{Id: 3}, nil).Once()
+	repo.On("SetUserDisabled", int64(3), true).Return(int64(1), nil)
+	repo.On("GetActiveSessions", int64(3)).Return([]entity.Session{}, nil)
+	repo.On("RevokeUserSessions", int64(3), "").Return(int64(0), nil)
+
+	router := gin.New()
+	router.POST("/admin/users/:id/disable", withUser, handler.AdminUserHandlerDisable)
+	req, err := http.NewRequest(http.MethodPost, "/admin/users/3/disable", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// instance yang menonaktifkan akun tidak menunggu cache kadaluwarsa
+	disabledAt := time.Now()
+	repo.On("GetUserByID", int64(3)).Return(&entity.User{Id: 3, DisabledAt: &disabledAt}, nil).Once()
+	user, err = handler.Accounts.GetUserByID(3)
+	require.NoError(t, err)
+	assert.NotNil(t, user.DisabledAt)
+}
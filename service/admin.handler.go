@@ -0,0 +1,350 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+	"todoGin/repository"
+)
+
+// AdminTodoHandlerGetAll menampilkan todo dari semua akun per halaman.
+func (h *Handler) AdminTodoHandlerGetAll(ctx *gin.Context) {
+	page, perPage := parsePagination(ctx)
+
+	todos, total, err := h.TodoRepository.GetAll(page, perPage)
+	if err != nil {
+		logrus.Errorf("failed when get all todos: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.PageResponse{
+		Status:  http.StatusOK,
+		Data:    todos,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+func (h *Handler) AdminUserHandlerGetAll(ctx *gin.Context) {
+	page, perPage := parsePagination(ctx)
+	query := ctx.Query("q")
+
+	users, total, err := h.TodoRepository.GetUsers(query, page, perPage)
+	if err != nil {
+		logrus.Errorf("failed when get users: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ids := make([]int64, len(users))
+	for i, user := range users {
+		ids[i] = user.Id
+	}
+	roles, err := h.TodoRepository.GetRolesByUsers(ids)
+	if err != nil {
+		logrus.Errorf("failed when get user roles: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	data := make([]request.UserResponse, len(users))
+	for i, user := range users {
+		data[i] = request.UserResponse{
+			ID:                    user.Id,
+			Username:              user.Username,
+			Roles:                 roles[user.Id],
+			Disabled:              user.DisabledAt != nil,
+			PasswordResetRequired: user.PasswordResetRequired,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, request.PageResponse{
+		Status:  http.StatusOK,
+		Data:    data,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// loadTargetUser mengambil user dari param :id untuk route admin.
+// Admin tidak bisa menonaktifkan, mereset, atau menghapus akunnya sendiri.
+func (h *Handler) loadTargetUser(ctx *gin.Context) (*entity.User, bool) {
+	adminID, ok := userIDFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	userID, ok := paramID(ctx, "id")
+	if !ok {
+		return nil, false
+	}
+	if userID == adminID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Cannot do this on your own account",
+			Status:  http.StatusBadRequest,
+		})
+		return nil, false
+	}
+
+	user, err := h.TodoRepository.GetUserByID(userID)
+	if err != nil {
+		logrus.Errorf("failed when get user by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	if user == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return user, true
+}
+
+func (h *Handler) AdminUserHandlerDisable(ctx *gin.Context) {
+	h.setUserDisabled(ctx, true)
+}
+
+func (h *Handler) AdminUserHandlerEnable(ctx *gin.Context) {
+	h.setUserDisabled(ctx, false)
+}
+
+// setUserDisabled dipakai bersama oleh route disable dan enable.
+func (h *Handler) setUserDisabled(ctx *gin.Context, disabled bool) {
+	user, ok := h.loadTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	if _, err := h.TodoRepository.SetUserDisabled(user.Id, disabled); err != nil {
+		logrus.Errorf("failed when updating account status: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.Accounts.Forget(user.Id)
+
+	message := "User enabled"
+	if disabled {
+		if _, err := h.revokeUserSessions(user.Id, ""); err != nil {
+			logrus.Errorf("failed when revoking sessions: %v", err)
+		}
+		message = "User disabled"
+	}
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    gin.H{"user_id": user.Id, "disabled": disabled},
+	})
+}
+
+// AdminUserHandlerResetPassword mengganti password user dengan password sementara.
+// User harus menggantinya lewat /password-reset sebelum bisa login lagi, token lamanya juga ditolak.
+func (h *Handler) AdminUserHandlerResetPassword(ctx *gin.Context) {
+	user, ok := h.loadTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	temporaryPassword, err := generateTemporaryPassword()
+	if err != nil {
+		logrus.Errorf("failed when generating temporary password: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.DefaultCost)
+	if err != nil {
+		logrus.Errorf("failed when hashing temporary password: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if _, err := h.TodoRepository.UpdatePassword(user.Id, string(hashedPassword), true); err != nil {
+		logrus.Errorf("failed when forcing password reset: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.Accounts.Forget(user.Id)
+
+	if _, err := h.revokeUserSessions(user.Id, ""); err != nil {
+		logrus.Errorf("failed when revoking sessions: %v", err)
+	}
+
+	// password sementara hanya ditampilkan sekali di sini
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Password reset required on next login",
+		Data:    gin.H{"user_id": user.Id, "temporary_password": temporaryPassword},
+	})
+}
+
+// AdminUserHandlerDelete menghapus user beserta todo dan file lampirannya.
+func (h *Handler) AdminUserHandlerDelete(ctx *gin.Context) {
+	user, ok := h.loadTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	isDeleted, err := h.TodoRepository.DeleteUser(user.Id)
+	if err != nil {
+		logrus.Errorf("failed when deleting user: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.Accounts.Forget(user.Id)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "User deleted",
+	})
+}
+
+// generateTemporaryPassword membuat password acak 16 karakter.
+func generateTemporaryPassword() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (h *Handler) AdminRoleHandlerAssign(ctx *gin.Context) {
+	userID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	role := ctx.Param("role")
+
+	user, err := h.TodoRepository.GetUserByID(userID)
+	if err != nil {
+		logrus.Errorf("failed when get user by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if user == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	assigned, err := h.TodoRepository.AssignRole(userID, role)
+	if err != nil {
+		logrus.Errorf("failed when assigning role: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if !assigned {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Role not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Role assigned",
+		Data:    gin.H{"user_id": userID, "role": role},
+	})
+}
+
+func (h *Handler) AdminRoleHandlerRemove(ctx *gin.Context) {
+	userID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	role := ctx.Param("role")
+
+	// admin tidak boleh mencabut role admin miliknya sendiri
+	currentUserID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	if userID == currentUserID && role == entity.RoleAdmin {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "You cannot remove your own admin role",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	isRemoved, err := h.TodoRepository.RemoveRole(userID, role)
+	if errors.Is(err, repository.ErrLastAdmin) {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "Cannot remove the last admin",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when removing role: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isRemoved == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User does not have this role",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Role removed",
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+func serveAdmin(t *testing.T, repo *mocks.TodoRepository, method, target string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.GET("/admin/todos", withUser, handler.AdminTodoHandlerGetAll)
+	router.DELETE("/admin/users/:id/roles/:role", withUser, handler.AdminRoleHandlerRemove)
+
+	req, err := http.NewRequest(method, target, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminTodosPaginated(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetAll", 3, 20).Return([]entity.Todolist{{ID: 41, UserID: 2}}, int64(45), nil)
+
+	w := serveAdmin(t, repo, http.MethodGet, "/admin/todos?page=3&per_page=20")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":45`)
+	assert.Contains(t, w.Body.String(), `"page":3`)
+
+	// per_page di luar batas kembali ke default, bukan mengambil semua todo
+	repo.On("GetAll", 1, 10).Return([]entity.Todolist{}, int64(45), nil)
+	w = serveAdmin(t, repo, http.MethodGet, "/admin/todos?per_page=100000")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAdminRemoveRole(t *testing.T) {
+	// admin yang sedang login (user 1) tidak bisa mencabut role admin-nya sendiri
+	repo := mocks.NewTodoRepository(t)
+	w := serveAdmin(t, repo, http.MethodDelete, "/admin/users/1/roles/admin")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	repo.AssertNumberOfCalls(t, "RemoveRole", 0)
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("RemoveRole", int64(2), entity.RoleAdmin).Return(int64(0), repository.ErrLastAdmin)
+	w = serveAdmin(t, repo, http.MethodDelete, "/admin/users/2/roles/admin")
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
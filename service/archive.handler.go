@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// TodolistArchivedHandler menampilkan todo yang sudah diarsipkan dengan paginasi.
+func (h *Handler) TodolistArchivedHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	page, perPage := parsePagination(ctx)
+
+	todos, total, err := h.repo(ctx).GetArchivedByUser(userID, page, perPage)
+	if err != nil {
+		logrus.Errorf("failed when get archived todos: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SearchResponse{
+		Status: http.StatusOK,
+		Data:   todos,
+		Total:  total,
+	})
+}
+
+func (h *Handler) TodolistArchiveHandler(ctx *gin.Context) {
+	h.setArchived(ctx, true)
+}
+
+func (h *Handler) TodolistUnarchiveHandler(ctx *gin.Context) {
+	h.setArchived(ctx, false)
+}
+
+func (h *Handler) setArchived(ctx *gin.Context, archived bool) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isUpdated, err := h.repo(ctx).SetArchived(todoID, userID, archived)
+	if err != nil {
+		logrus.Errorf("failed when archiving todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isUpdated == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Todo not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	message, action := "Todo archived", entity.HistoryArchived
+	if !archived {
+		message, action = "Todo unarchived", entity.HistoryUnarchived
+	}
+	h.recordHistory(todoID, userID, action, nil, nil)
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: message,
+	})
+}
+
+// AutoArchiveSettingHandler mengatur berapa hari todo done disimpan sebelum otomatis diarsipkan.
+func (h *Handler) AutoArchiveSettingHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.AutoArchiveRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "days must be between 0 and 3650",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.repo(ctx).UpdateAutoArchiveDays(userID, *reqBody.Days); err != nil {
+		logrus.Errorf("failed when updating auto archive setting: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Auto Archive",
+		Data:    gin.H{"auto_archive_days": *reqBody.Days},
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+)
+
+func TestAutoArchiveSetting(t *testing.T) {
+	put := func(repo *mocks.TodoRepository, body string) int {
+		handler := NewTodoService(repo)
+		router := gin.New()
+		router.PUT("/settings/auto-archive", withUser, handler.AutoArchiveSettingHandler)
+
+		req, err := http.NewRequest(http.MethodPut, "/settings/auto-archive", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// 0 hari mematikan auto-archive dan tetap valid
+	repo := mocks.NewTodoRepository(t)
+	repo.On("UpdateAutoArchiveDays", int64(1), 0).Return(nil)
+	assert.Equal(t, http.StatusOK, put(repo, `{"days": 0}`))
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("UpdateAutoArchiveDays", int64(1), 30).Return(nil)
+	assert.Equal(t, http.StatusOK, put(repo, `{"days": 30}`))
+
+	for _, body := range []string{`{}`, `{"days": -1}`, `{"days": 3651}`} {
+		repo = mocks.NewTodoRepository(t)
+		assert.Equal(t, http.StatusBadRequest, put(repo, body), body)
+		repo.AssertNumberOfCalls(t, "UpdateAutoArchiveDays", 0)
+	}
+}
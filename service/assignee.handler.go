@@ -0,0 +1,109 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// TodoAssignHandler mengganti assignee todo. Hanya owner dan assignee saat ini
+// yang boleh mengubah assignment, dan assignee baru harus owner, user yang mendapat share,
+// atau anggota workspace todo.
+func (h *Handler) TodoAssignHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TodoAssignRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	todo, ok := h.loadTodo(ctx, todoID, userID)
+	if !ok {
+		return
+	}
+	if todo.AccessRole != entity.AccessOwner && todo.AccessRole != entity.AccessAssignee {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the owner or the current assignee can change the assignment",
+			Status:  http.StatusForbidden,
+		})
+		return
+	}
+
+	// assignee harus sudah punya hubungan dengan todo, user lain dijawab 404
+	// supaya endpoint ini tidak bisa dipakai untuk menebak user id
+	if reqBody.AssigneeID != nil {
+		assignable, err := h.repo(ctx).IsAssignable(todo, *reqBody.AssigneeID)
+		if err != nil {
+			logrus.Errorf("failed when checking assignee: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if !assignable {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+				Message: "Assignee not found",
+				Status:  http.StatusNotFound,
+			})
+			return
+		}
+	}
+
+	if err := h.repo(ctx).AssignTodo(todoID, reqBody.AssigneeID); err != nil {
+		logrus.Errorf("failed when assigning todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryAssigned,
+		gin.H{"assignee_id": todo.AssigneeID}, gin.H{"assignee_id": reqBody.AssigneeID})
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Assignee updated",
+		Data:    gin.H{"todo_id": todoID, "assignee_id": reqBody.AssigneeID},
+	})
+}
+
+// AssignedToMeHandler menampilkan todo yang di-assign ke user ini.
+func (h *Handler) AssignedToMeHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	todos, err := h.repo(ctx).GetAssignedToUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get assigned todos: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoResponseToGetAll{
+		Message: "Success Get Assigned Todos",
+		UserId:  userID,
+		Data:    len(todos),
+		Todos:   todos,
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func TestCanEditTodo(t *testing.T) {
+	assert.True(t, canEditTodo(entity.AccessOwner))
+	assert.True(t, canEditTodo(entity.AccessAssignee))
+	assert.True(t, canEditTodo(entity.ShareRoleEditor))
+	assert.False(t, canEditTodo(entity.ShareRoleViewer))
+	assert.False(t, canEditTodo(""))
+}
+
+func assignTodo(t *testing.T, repo *mocks.TodoRepository, body string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id/assignee", withUser, handler.TodoAssignHandler)
+
+	req, err := http.NewRequest(http.MethodPut, "/manage-todo/todo/1/assignee", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAssignTodo(t *testing.T) {
+	assigneeID := int64(1)
+	// todo milik user 2, user 1 yang sedang login punya role berbeda-beda
+	todoFor := func(role string) *entity.Todolist {
+		return &entity.Todolist{ID: 1, UserID: 2, AssigneeID: &assigneeID, AccessRole: role}
+	}
+
+	t.Run("OwnerReassigns", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("IsAssignable", mock.Anything, int64(3)).Return(true, nil)
+		repo.On("AssignTodo", int64(1), mock.MatchedBy(func(id *int64) bool { return id != nil && *id == 3 })).Return(nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := assignTodo(t, repo, `{"assignee_id": 3}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "assignee_username")
+	})
+
+	t.Run("AssigneeUnassignsItself", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(todoFor(entity.AccessAssignee), nil)
+		repo.On("AssignTodo", int64(1), (*int64)(nil)).Return(nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := assignTodo(t, repo, `{"assignee_id": null}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	// editor boleh mengubah isi todo, tapi tidak boleh memindahkan tugas
+	for _, role := range []string{entity.ShareRoleEditor, entity.ShareRoleViewer} {
+		t.Run(role, func(t *testing.T) {
+			repo := mocks.NewTodoRepository(t)
+			repo.On("GetByID", int64(1), int64(1)).Return(todoFor(role), nil)
+
+			w := assignTodo(t, repo, `{"assignee_id": 3}`)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			repo.AssertNumberOfCalls(t, "AssignTodo", 0)
+		})
+	}
+
+	// user yang tidak punya hubungan dengan todo dijawab sama seperti user yang tidak ada
+	t.Run("UnrelatedAssignee", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("IsAssignable", mock.Anything, int64(99)).Return(false, nil)
+
+		w := assignTodo(t, repo, `{"assignee_id": 99}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		repo.AssertNumberOfCalls(t, "AssignTodo", 0)
+	})
+}
+
+func TestAssigneeEditsTodo(t *testing.T) {
+	assigneeID := int64(1)
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{
+		ID: 1, UserID: 2, AssigneeID: &assigneeID, Status: entity.StatusTodo, AccessRole: entity.AccessAssignee,
+	}, nil)
+	repo.On("Update", int64(1), int64(2), mock.Anything).Return(&entity.Todolist{ID: 1, UserID: 2}, nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Dikerjakan assignee"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) ChecklistHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.ChecklistItemCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadEditableTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	item := &entity.ChecklistItem{
+		TodoID: todoID,
+		Title:  reqBody.Title,
+	}
+	if err := h.repo(ctx).CreateChecklistItem(item); err != nil {
+		logrus.Errorf("failed when creating checklist item: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryChecklistAdded, nil, item)
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "New Checklist Item Created",
+		Data:    item,
+	})
+}
+
+func (h *Handler) ChecklistHandlerUpdate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := paramID(ctx, "item_id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.ChecklistItemUpdateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadEditableTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	item, err := h.repo(ctx).GetChecklistItem(itemID, todoID)
+	if err != nil {
+		logrus.Errorf("failed when get checklist item: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if item == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Checklist item not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	updates := reqBody.ReqChecklistItem()
+	if len(updates) == 0 {
+		ctx.AbortWithStatusJSON(http.StatusOK, request.TodoIDResponse{
+			Message: "Not Change",
+			Data:    item,
+		})
+		return
+	}
+	if err := h.repo(ctx).UpdateChecklistItem(itemID, todoID, updates); err != nil {
+		logrus.Errorf("failed when updating checklist item: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryChecklistUpdated, item, updates)
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Checklist Item",
+		Data:    reqBody,
+	})
+}
+
+func (h *Handler) ChecklistHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := paramID(ctx, "item_id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadEditableTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	isDeleted, err := h.repo(ctx).DeleteChecklistItem(itemID, todoID)
+	if err != nil {
+		logrus.Errorf("failed when deleting checklist item: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Checklist item not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryChecklistDeleted, gin.H{"id": itemID}, nil)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Delete Checklist Item",
+	})
+}
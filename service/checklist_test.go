@@ -0,0 +1,66 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func putChecklistItem(t *testing.T, repo *mocks.TodoRepository, body string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id/checklist/:item_id", withUser, handler.ChecklistHandlerUpdate)
+
+	req, err := http.NewRequest(http.MethodPut, "/manage-todo/todo/1/checklist/5", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestChecklistItemDone(t *testing.T) {
+	t.Run("MarkDoneSetsCompletedAt", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetChecklistItem", int64(5), int64(1)).Return(&entity.ChecklistItem{ID: 5, TodoID: 1}, nil)
+		repo.On("UpdateChecklistItem", int64(5), int64(1), mock.MatchedBy(func(updates map[string]interface{}) bool {
+			completedAt, ok := updates["completed_at"].(time.Time)
+			return updates["done"] == true && ok && !completedAt.IsZero()
+		})).Return(nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := putChecklistItem(t, repo, `{"done": true}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ReopenClearsCompletedAt", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetChecklistItem", int64(5), int64(1)).Return(&entity.ChecklistItem{ID: 5, TodoID: 1, Done: true}, nil)
+		repo.On("UpdateChecklistItem", int64(5), int64(1), mock.MatchedBy(func(updates map[string]interface{}) bool {
+			completedAt, present := updates["completed_at"]
+			return updates["done"] == false && present && completedAt == nil
+		})).Return(nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := putChecklistItem(t, repo, `{"done": false}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OtherUsersTodo", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(nil, nil)
+
+		w := putChecklistItem(t, repo, `{"done": true}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		repo.AssertNumberOfCalls(t, "UpdateChecklistItem", 0)
+	})
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) CommentHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	page, perPage := parsePagination(ctx)
+	comments, total, err := h.repo(ctx).GetComments(todoID, page, perPage)
+	if err != nil {
+		logrus.Errorf("failed when get comments: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.PageResponse{
+		Status:  http.StatusOK,
+		Data:    comments,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+func (h *Handler) CommentHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.CommentRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	comment := &entity.Comment{
+		TodoID: todoID,
+		UserID: userID,
+		Body:   reqBody.Body,
+	}
+	if err := h.repo(ctx).CreateComment(comment); err != nil {
+		logrus.Errorf("failed when creating comment: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	comment.AuthorUsername = ctx.GetString("username")
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "New Comment Created",
+		Data:    comment,
+	})
+}
+
+func (h *Handler) CommentHandlerUpdate(ctx *gin.Context) {
+	reqBody := new(request.CommentRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	comment, ok := h.loadOwnComment(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.repo(ctx).UpdateComment(comment.ID, reqBody.Body); err != nil {
+		logrus.Errorf("failed when updating comment: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Comment",
+		Data:    reqBody,
+	})
+}
+
+func (h *Handler) CommentHandlerDelete(ctx *gin.Context) {
+	comment, ok := h.loadOwnComment(ctx)
+	if !ok {
+		return
+	}
+
+	if _, err := h.repo(ctx).DeleteComment(comment.ID); err != nil {
+		logrus.Errorf("failed when deleting comment: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Delete Comment",
+	})
+}
+
+// loadOwnComment mengambil comment dari path dan memastikan hanya penulisnya
+// yang boleh mengubah atau menghapus.
+func (h *Handler) loadOwnComment(ctx *gin.Context) (*entity.Comment, bool) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return nil, false
+	}
+	commentID, ok := paramID(ctx, "comment_id")
+	if !ok {
+		return nil, false
+	}
+
+	if _, ok := h.loadTodo(ctx, todoID, userID); !ok {
+		return nil, false
+	}
+
+	comment, err := h.repo(ctx).GetComment(commentID, todoID)
+	if err != nil {
+		logrus.Errorf("failed when get comment: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	if comment == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Comment not found",
+			Status:  http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if comment.UserID != userID {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the author can change this comment",
+			Status:  http.StatusForbidden,
+		})
+		return nil, false
+	}
+
+	return comment, true
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func serveComment(t *testing.T, repo *mocks.TodoRepository, method string, body io.Reader) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id/comments/:comment_id", withUser, handler.CommentHandlerUpdate)
+	router.DELETE("/manage-todo/todo/:id/comments/:comment_id", withUser, handler.CommentHandlerDelete)
+
+	req, err := http.NewRequest(method, "/manage-todo/todo/1/comments/8", body)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// commentRepo menyiapkan todo 1 milik user 1 dengan comment 8 yang ditulis authorID.
+func commentRepo(t *testing.T, authorID int64) *mocks.TodoRepository {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("GetComment", int64(8), int64(1)).Return(&entity.Comment{ID: 8, TodoID: 1, UserID: authorID, Body: "awal"}, nil)
+	return repo
+}
+
+func TestCommentAuthorCanEdit(t *testing.T) {
+	repo := commentRepo(t, 1)
+	repo.On("UpdateComment", int64(8), "sudah direvisi").Return(nil)
+	w := serveComment(t, repo, http.MethodPut, bytes.NewBufferString(`{"body": "sudah direvisi"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	repo = commentRepo(t, 1)
+	repo.On("DeleteComment", int64(8)).Return(int64(1), nil)
+	w = serveComment(t, repo, http.MethodDelete, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCommentOthersCannotEdit(t *testing.T) {
+	// pemilik todo pun tidak boleh mengubah comment orang lain
+	repo := commentRepo(t, 2)
+	w := serveComment(t, repo, http.MethodPut, bytes.NewBufferString(`{"body": "diubah pemilik todo"}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "UpdateComment", 0)
+
+	repo = commentRepo(t, 2)
+	w = serveComment(t, repo, http.MethodDelete, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "DeleteComment", 0)
+}
+
+func TestCommentNotOnThisTodo(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("GetComment", int64(8), int64(1)).Return(nil, nil)
+
+	w := serveComment(t, repo, http.MethodDelete, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"sync"
+	"time"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+// TokenDenylist mengecek apakah access token sudah logout. Sumber datanya tabel revoked_tokens,
+// hasilnya di-cache supaya Authmiddleware tidak query database di setiap request.
+// Token yang belum dicabut hanya di-cache selama cacheTTL, jadi logout dari instance lain
+// paling lambat berlaku setelah cacheTTL.
+type TokenDenylist struct {
+	repo     repository.TodoRepository
+	cacheTTL time.Duration
+
+	mu      sync.RWMutex
+	revoked map[string]time.Time // jti -> kapan token kadaluwarsa
+	allowed map[string]time.Time // jti -> kapan cache harus dicek ulang
+}
+
+func NewTokenDenylist(repo repository.TodoRepository, cacheTTL time.Duration) *TokenDenylist {
+	return &TokenDenylist{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		revoked:  make(map[string]time.Time),
+		allowed:  make(map[string]time.Time),
+	}
+}
+
+func (d *TokenDenylist) IsRevoked(jti string) (bool, error) {
+	now := time.Now()
+
+	d.mu.RLock()
+	_, revoked := d.revoked[jti]
+	recheckAt, cached := d.allowed[jti]
+	d.mu.RUnlock()
+	if revoked {
+		return true, nil
+	}
+	if cached && now.Before(recheckAt) {
+		return false, nil
+	}
+
+	token, err := d.repo.GetRevokedToken(jti)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if token != nil {
+		d.revoked[jti] = token.ExpiresAt
+		delete(d.allowed, jti)
+		return true, nil
+	}
+	d.allowed[jti] = now.Add(d.cacheTTL)
+	return false, nil
+}
+
+// Revoke menyimpan jti ke database dulu, baru ke cache.
+func (d *TokenDenylist) Revoke(jti string, userID int64, expiresAt time.Time) error {
+	err := d.repo.RevokeToken(&entity.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.revoked[jti] = expiresAt
+	delete(d.allowed, jti)
+	return nil
+}
+
+// Prune membuang isi cache yang sudah tidak diperlukan.
+func (d *TokenDenylist) Prune(now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for jti, expiresAt := range d.revoked {
+		if expiresAt.Before(now) {
+			delete(d.revoked, jti)
+		}
+	}
+	for jti, recheckAt := range d.allowed {
+		if recheckAt.Before(now) {
+			delete(d.allowed, jti)
+		}
+	}
+}
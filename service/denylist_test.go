@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func TestTokenDenylistLogoutFlow(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	denylist := NewTokenDenylist(repo, time.Minute)
+	expiresAt := time.Now().Add(time.Hour)
+
+	// request pertama cek ke database, berikutnya dari cache
+	repo.On("GetRevokedToken", "jti-a").Return(nil, nil).Once()
+	for i := 0; i < 3; i++ {
+		revoked, err := denylist.IsRevoked("jti-a")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	}
+
+	// logout langsung berlaku di instance ini walaupun token sempat di-cache sebagai aktif
+	repo.On("RevokeToken", &entity.RevokedToken{JTI: "jti-a", UserID: 1, ExpiresAt: expiresAt}).Return(nil).Once()
+	require.NoError(t, denylist.Revoke("jti-a", 1, expiresAt))
+
+	revoked, err := denylist.IsRevoked("jti-a")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	repo.AssertNumberOfCalls(t, "GetRevokedToken", 1)
+}
+
+func TestTokenDenylistLogoutFromOtherInstance(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	// cacheTTL 0: token aktif selalu dicek ulang ke database
+	denylist := NewTokenDenylist(repo, 0)
+
+	repo.On("GetRevokedToken", "jti-b").Return(nil, nil).Once()
+	revoked, err := denylist.IsRevoked("jti-b")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// instance lain menyimpan jti-b ke revoked_tokens
+	repo.On("GetRevokedToken", "jti-b").Return(&entity.RevokedToken{JTI: "jti-b", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+	revoked, err = denylist.IsRevoked("jti-b")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// setelah ketahuan dicabut tidak perlu query lagi
+	revoked, _ = denylist.IsRevoked("jti-b")
+	assert.True(t, revoked)
+	repo.AssertNumberOfCalls(t, "GetRevokedToken", 2)
+}
+
+func TestTokenDenylistErrors(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	denylist := NewTokenDenylist(repo, time.Minute)
+	dbErr := errors.New("database down")
+
+	// error tidak di-cache sebagai token aktif
+	repo.On("GetRevokedToken", "jti-c").Return(nil, dbErr).Twice()
+	_, err := denylist.IsRevoked("jti-c")
+	assert.ErrorIs(t, err, dbErr)
+	_, err = denylist.IsRevoked("jti-c")
+	assert.ErrorIs(t, err, dbErr)
+
+	// logout yang gagal disimpan tidak boleh terlihat berhasil dari cache
+	repo.On("RevokeToken", mock.Anything).Return(dbErr).Once()
+	assert.ErrorIs(t, denylist.Revoke("jti-d", 1, time.Now().Add(time.Hour)), dbErr)
+	repo.On("GetRevokedToken", "jti-d").Return(nil, nil).Once()
+	revoked, err := denylist.IsRevoked("jti-d")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestTokenDenylistPrune(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	denylist := NewTokenDenylist(repo, time.Minute)
+	now := time.Now()
+
+	repo.On("RevokeToken", mock.Anything).Return(nil)
+	require.NoError(t, denylist.Revoke("kadaluwarsa", 1, now.Add(-time.Second)))
+	require.NoError(t, denylist.Revoke("masih-berlaku", 1, now.Add(time.Hour)))
+
+	denylist.Prune(now)
+
+	assert.NotContains(t, denylist.revoked, "kadaluwarsa")
+	assert.Contains(t, denylist.revoked, "masih-berlaku")
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+	"todoGin/repository"
+)
+
+func (h *Handler) DependencyHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.DependencyCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if reqBody.BlockedByID == todoID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "A todo cannot block itself",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// kedua todo harus milik user yang sama
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return
+	}
+	if _, ok := h.loadOwnTodo(ctx, reqBody.BlockedByID, userID); !ok {
+		return
+	}
+
+	err := h.repo(ctx).AddDependency(todoID, reqBody.BlockedByID, userID)
+	if errors.Is(err, repository.ErrDependencyCycle) {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "Dependency would create a cycle",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when adding dependency: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryDependencyAdded, nil, gin.H{"blocked_by_id": reqBody.BlockedByID})
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Dependency added",
+		Data:    gin.H{"todo_id": todoID, "blocked_by_id": reqBody.BlockedByID},
+	})
+}
+
+func (h *Handler) DependencyHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	blockerID, ok := paramID(ctx, "blocker_id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	isDeleted, err := h.repo(ctx).RemoveDependency(todoID, blockerID)
+	if err != nil {
+		logrus.Errorf("failed when removing dependency: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Dependency not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryDependencyRemoved, gin.H{"blocked_by_id": blockerID}, nil)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Dependency removed",
+	})
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+func addDependency(t *testing.T, repo *mocks.TodoRepository, body string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/manage-todo/todo/:id/dependencies", withUser, handler.DependencyHandlerCreate)
+
+	req, err := http.NewRequest(http.MethodPost, "/manage-todo/todo/1/dependencies", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDependencyCreate(t *testing.T) {
+	t.Run("Added", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("AddDependency", int64(1), int64(2), int64(1)).Return(nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := addDependency(t, repo, `{"blocked_by_id": 2}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		// siklus dicek repository di dalam transaksi yang sama dengan insert
+		repo.On("AddDependency", int64(1), int64(2), int64(1)).Return(repository.ErrDependencyCycle)
+
+		w := addDependency(t, repo, `{"blocked_by_id": 2}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		repo.AssertNumberOfCalls(t, "AddHistory", 0)
+	})
+
+	t.Run("Itself", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		w := addDependency(t, repo, `{"blocked_by_id": 1}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUpdateBlockedTodoToDone(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{
+		ID: 1, UserID: 1, AccessRole: entity.AccessOwner, Status: entity.StatusInProgress, Blocked: true, BlockedBy: []int64{4},
+	}, nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"status": "done"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "[4]")
+	repo.AssertNumberOfCalls(t, "ChangeStatus", 0)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// userIDFromContext mengambil user_id yang di-set Authmiddleware.
+// Kalau tidak ada atau tipenya salah, request langsung di-abort.
+func userIDFromContext(ctx *gin.Context) (int64, bool) {
+	userID, _ := ctx.Get("user_id")
+	if userID == nil {
+		logrus.Error("User not authenticated")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.ErrorResponse{
+			Message: "User not authenticated",
+			Status:  http.StatusUnauthorized,
+		})
+		return 0, false
+	}
+
+	userIDInt64, ok := userID.(int64)
+	if !ok {
+		logrus.Error("Invalid user_id")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid user_id",
+			Status:  http.StatusBadRequest,
+		})
+		return 0, false
+	}
+
+	return userIDInt64, true
+}
+
+// paramID membaca path parameter bertipe int64, request di-abort kalau tidak valid.
+func paramID(ctx *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param(name), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Parse ID Error",
+			Status:  http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// parsePagination membaca page dan per_page dari query string dengan batas per_page 100.
+func parsePagination(ctx *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(ctx.DefaultQuery("per_page", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 10
+	}
+	return page, perPage
+}
+
+// loadTodo mengambil todo milik user, request di-abort kalau tidak ditemukan.
+func (h *Handler) loadTodo(ctx *gin.Context, todoID, userID int64) (*entity.Todolist, bool) {
+	todo, err := h.repo(ctx).GetByID(todoID, userID)
+	if err != nil {
+		logrus.Errorf("failed when get todo by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	if todo == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Todo not found",
+			Status:  http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return todo, true
+}
+
+// loadEditableTodo sama seperti loadTodo tapi hanya untuk owner, assignee atau editor.
+func (h *Handler) loadEditableTodo(ctx *gin.Context, todoID, userID int64) (*entity.Todolist, bool) {
+	todo, ok := h.loadTodo(ctx, todoID, userID)
+	if !ok {
+		return nil, false
+	}
+	if !canEditTodo(todo.AccessRole) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "You only have view access to this todo",
+			Status:  http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return todo, true
+}
+
+// loadOwnTodo sama seperti loadTodo tapi hanya untuk pemilik todo.
+func (h *Handler) loadOwnTodo(ctx *gin.Context, todoID, userID int64) (*entity.Todolist, bool) {
+	todo, ok := h.loadTodo(ctx, todoID, userID)
+	if !ok {
+		return nil, false
+	}
+	if todo.AccessRole != entity.AccessOwner {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the owner can do this",
+			Status:  http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return todo, true
+}
+
+// sameTodoList: todo di workspace yang sama ada di satu list, di luar workspace list-nya per owner.
+func sameTodoList(a, b *entity.Todolist) bool {
+	if a.WorkspaceID != nil || b.WorkspaceID != nil {
+		return a.WorkspaceID != nil && b.WorkspaceID != nil && *a.WorkspaceID == *b.WorkspaceID
+	}
+	return a.UserID == b.UserID
+}
+
+// canEditTodo: viewer hanya boleh membaca, selain itu boleh mengubah isi todo.
+func canEditTodo(role string) bool {
+	return role == entity.AccessOwner || role == entity.AccessManager ||
+		role == entity.AccessAssignee || role == entity.ShareRoleEditor
+}
+
+// canDeleteTodo: selain pemilik, admin workspace juga boleh menghapus todo.
+func canDeleteTodo(role string) bool {
+	return role == entity.AccessOwner || role == entity.AccessManager
+}
+
+// parseTodoFilter membaca filter list todo dari query string,
+// contoh: ?labels=1,2&label_match=all&project_id=3&sort=priority,-due_at
+func parseTodoFilter(ctx *gin.Context) (request.TodoFilter, bool) {
+	filter := request.TodoFilter{
+		LabelMatch:      ctx.DefaultQuery("label_match", request.LabelMatchAny),
+		IncludeArchived: ctx.Query("include_archived") == "true",
+	}
+
+	if filter.LabelMatch != request.LabelMatchAny && filter.LabelMatch != request.LabelMatchAll {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "label_match must be any or all",
+			Status:  http.StatusBadRequest,
+		})
+		return filter, false
+	}
+
+	if raw := ctx.Query("project_id"); raw != "" {
+		projectID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: "Invalid project_id filter",
+				Status:  http.StatusBadRequest,
+			})
+			return filter, false
+		}
+		filter.ProjectID = &projectID
+	}
+
+	for _, raw := range strings.Split(ctx.Query("sort"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		field := request.SortField{Field: strings.TrimPrefix(raw, "-"), Desc: strings.HasPrefix(raw, "-")}
+		if !isSortableTodoField(field.Field) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: fmt.Sprintf("Cannot sort by %q", field.Field),
+				Status:  http.StatusBadRequest,
+			})
+			return filter, false
+		}
+		filter.Sort = append(filter.Sort, field)
+	}
+
+	seen := make(map[int64]bool)
+	for _, raw := range strings.Split(ctx.Query("labels"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		labelID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: "Invalid labels filter",
+				Status:  http.StatusBadRequest,
+			})
+			return filter, false
+		}
+		if !seen[labelID] {
+			seen[labelID] = true
+			filter.LabelIDs = append(filter.LabelIDs, labelID)
+		}
+	}
+
+	return filter, true
+}
+
+func isSortableTodoField(field string) bool {
+	for _, sortable := range request.TodoSortFields {
+		if sortable == field {
+			return true
+		}
+	}
+	return false
+}
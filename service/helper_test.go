@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+)
+
+func getTodos(t *testing.T, repo *mocks.TodoRepository, target string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.GET("/manage-todos", withUser, handler.TodolistHandlerGetAll)
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLabelFilter(t *testing.T) {
+	t.Run("DefaultsToAny", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetAllUserByID", int64(1), request.TodoFilter{
+			LabelIDs:   []int64{3},
+			LabelMatch: request.LabelMatchAny,
+		}).Return([]entity.Todolist{}, nil)
+
+		w := getTodos(t, repo, "/manage-todos?labels=3")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("AllWithDuplicates", func(t *testing.T) {
+		// label yang sama dua kali tidak boleh membuat match=all mustahil terpenuhi
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetAllUserByID", int64(1), request.TodoFilter{
+			LabelIDs:   []int64{2, 1},
+			LabelMatch: request.LabelMatchAll,
+		}).Return([]entity.Todolist{}, nil)
+
+		w := getTodos(t, repo, "/manage-todos?labels=2,%201,2&label_match=all")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownMatch", func(t *testing.T) {
+		w := getTodos(t, mocks.NewTodoRepository(t), "/manage-todos?labels=1&label_match=some")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidLabelID", func(t *testing.T) {
+		w := getTodos(t, mocks.NewTodoRepository(t), "/manage-todos?labels=1,abc")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSortParam(t *testing.T) {
+	t.Run("MultipleKeys", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetAllUserByID", int64(1), mock.MatchedBy(func(filter request.TodoFilter) bool {
+			return assert.ObjectsAreEqual([]request.SortField{
+				{Field: "priority"},
+				{Field: "due_at", Desc: true},
+			}, filter.Sort)
+		})).Return([]entity.Todolist{}, nil)
+
+		w := getTodos(t, repo, "/manage-todos?sort=priority,-due_at")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	// nama kolom masuk ke ORDER BY, jadi selain daftar yang diizinkan harus ditolak
+	for _, sort := range []string{"password", "-user_id", "title DESC;--", "-"} {
+		t.Run("Reject "+sort, func(t *testing.T) {
+			w := getTodos(t, mocks.NewTodoRepository(t), "/manage-todos?sort="+url.QueryEscape(sort))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
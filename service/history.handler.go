@@ -0,0 +1,117 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) TodoHistoryHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	todo, err := h.repo(ctx).GetByID(todoID, userID)
+	if err == nil && todo == nil {
+		// history todo yang sudah di trash masih bisa dilihat pemiliknya
+		todo, err = h.repo(ctx).GetTrashedTodo(todoID, userID)
+		if todo != nil && todo.AccessRole != entity.AccessOwner {
+			todo = nil
+		}
+	}
+	if err != nil {
+		logrus.Errorf("failed when get todo by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if todo == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Todo not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	page, perPage := parsePagination(ctx)
+	histories, total, err := h.repo(ctx).GetHistory(todoID, page, perPage)
+	if err != nil {
+		logrus.Errorf("failed when get todo history: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.PageResponse{
+		Status:  http.StatusOK,
+		Data:    histories,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// recordHistory menyimpan satu baris history. Gagal menyimpan history tidak
+// membatalkan request, perubahannya sendiri sudah tersimpan.
+func (h *Handler) recordHistory(todoID, userID int64, action string, before, after interface{}) {
+	history := &entity.TodoHistory{
+		TodoID: todoID,
+		UserID: userID,
+		Action: action,
+	}
+
+	var err error
+	if before != nil {
+		if history.Before, err = json.Marshal(before); err != nil {
+			logrus.Errorf("failed when encoding history: %v", err)
+			return
+		}
+	}
+	if after != nil {
+		if history.After, err = json.Marshal(after); err != nil {
+			logrus.Errorf("failed when encoding history: %v", err)
+			return
+		}
+	}
+
+	if err := h.TodoRepository.AddHistory(history); err != nil {
+		logrus.Errorf("failed when recording todo history: %v", err)
+	}
+}
+
+// todoFieldValues mengambil nilai lama todo untuk kolom-kolom yang akan diupdate.
+func todoFieldValues(todo *entity.Todolist, updates map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(updates))
+	for column := range updates {
+		switch column {
+		case "title":
+			values[column] = todo.Title
+		case "description":
+			values[column] = todo.Description
+		case "start_at":
+			values[column] = todo.StartAt
+		case "due_at":
+			values[column] = todo.DueAt
+		case "recurrence":
+			values[column] = todo.Recurrence
+		case "priority":
+			values[column] = todo.Priority
+		case "estimate_minutes":
+			values[column] = todo.EstimateMinutes
+		}
+	}
+	return values
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+// captureHistory mencatat setiap baris history yang disimpan handler.
+func captureHistory(repo *mocks.TodoRepository) *[]entity.TodoHistory {
+	histories := new([]entity.TodoHistory)
+	repo.On("AddHistory", mock.Anything).Run(func(args mock.Arguments) {
+		*histories = append(*histories, *args.Get(0).(*entity.TodoHistory))
+	}).Return(nil)
+	return histories
+}
+
+func decodeValues(t *testing.T, raw json.RawMessage) map[string]interface{} {
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &values))
+	return values
+}
+
+func TestHistoryTitleAndStatusChange(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	histories := captureHistory(repo)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Title: "Draf", Status: entity.StatusTodo, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("ChangeStatus", int64(1), entity.StatusTodo, entity.StatusInProgress, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["title"] == "Final"
+	}), (*entity.Todolist)(nil)).Return(&entity.StatusTransition{TodoID: 1}, nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Final", "status": "in_progress"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// satu baris untuk perubahan kolom, satu baris untuk perubahan status
+	require.Len(t, *histories, 2)
+
+	updated := (*histories)[0]
+	assert.Equal(t, entity.HistoryUpdated, updated.Action)
+	assert.Equal(t, int64(1), updated.UserID)
+	assert.Equal(t, map[string]interface{}{"title": "Draf"}, decodeValues(t, updated.Before))
+	assert.Equal(t, map[string]interface{}{"title": "Final"}, decodeValues(t, updated.After))
+
+	changed := (*histories)[1]
+	assert.Equal(t, entity.HistoryStatusChanged, changed.Action)
+	assert.Equal(t, map[string]interface{}{"status": entity.StatusTodo}, decodeValues(t, changed.Before))
+	assert.Equal(t, map[string]interface{}{"status": entity.StatusInProgress}, decodeValues(t, changed.After))
+}
+
+func TestHistoryDeleteKeepsLastState(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	histories := captureHistory(repo)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Title: "Lama", UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("Delete", int64(1), int64(1)).Return(int64(1), nil)
+
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
+	req, err := http.NewRequest(http.MethodDelete, "/manage-todo/todo/1", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.Len(t, *histories, 1)
+	assert.Equal(t, entity.HistoryDeleted, (*histories)[0].Action)
+	assert.Equal(t, "Lama", decodeValues(t, (*histories)[0].Before)["title"])
+	assert.Empty(t, (*histories)[0].After)
+}
+
+func TestHistoryNotRecordedOnFailure(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Status: entity.StatusBacklog, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"status": "done"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	repo.AssertNumberOfCalls(t, "AddHistory", 0)
+}
+
+func TestHistoryList(t *testing.T) {
+	get := func(repo *mocks.TodoRepository, target string) *httptest.ResponseRecorder {
+		handler := NewTodoService(repo)
+		router := gin.New()
+		router.GET("/manage-todo/todo/:id/history", withUser, handler.TodoHistoryHandler)
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("GetHistory", int64(1), 2, 100).Return([]entity.TodoHistory{{ID: 5, Action: entity.HistoryCreated}}, int64(101), nil)
+	assert.Equal(t, http.StatusOK, get(repo, "/manage-todo/todo/1/history?page=2&per_page=100").Code)
+
+	// history todo milik user lain tidak boleh dibaca
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(2), int64(1)).Return(nil, nil)
+	repo.On("GetTrashedTodo", int64(2), int64(1)).Return(nil, nil)
+	assert.Equal(t, http.StatusNotFound, get(repo, "/manage-todo/todo/2/history").Code)
+	repo.AssertNumberOfCalls(t, "GetHistory", 0)
+
+	// todo di trash masih bisa dilihat history-nya oleh pemilik, tapi tidak oleh editor
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(3), int64(1)).Return(nil, nil)
+	repo.On("GetTrashedTodo", int64(3), int64(1)).Return(&entity.Todolist{ID: 3, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("GetHistory", int64(3), 1, 10).Return([]entity.TodoHistory{{ID: 9, Action: entity.HistoryDeleted}}, int64(1), nil)
+	w := get(repo, "/manage-todo/todo/3/history")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), entity.HistoryDeleted)
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(4), int64(1)).Return(nil, nil)
+	repo.On("GetTrashedTodo", int64(4), int64(1)).Return(&entity.Todolist{ID: 4, UserID: 2, AccessRole: entity.ShareRoleEditor}, nil)
+	assert.Equal(t, http.StatusNotFound, get(repo, "/manage-todo/todo/4/history").Code)
+	repo.AssertNumberOfCalls(t, "GetHistory", 0)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"time"
+	"todoGin/cfg"
+)
+
+// runPeriodically menjalankan job setiap interval sampai ctx dibatalkan.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(); err != nil {
+				logrus.Errorf("job %s failed: %v", name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StartTrashPurge menghapus permanen todo yang sudah di trash lebih lama dari retention.
+func (h *Handler) StartTrashPurge(ctx context.Context, retention, interval time.Duration) {
+	runPeriodically(ctx, "trash purge", interval, func() error {
+		purged, err := h.TodoRepository.PurgeTrash(time.Now().Add(-retention))
+		if purged > 0 {
+			logrus.Infof("purged %d todos from trash", purged)
+		}
+		return err
+	})
+}
+
+// StartAutoArchive mengarsipkan todo done sesuai pengaturan auto_archive_days tiap user.
+func (h *Handler) StartAutoArchive(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, "auto archive", interval, func() error {
+		archived, err := h.TodoRepository.ArchiveDoneTodos(time.Now())
+		if archived > 0 {
+			logrus.Infof("auto archived %d todos", archived)
+		}
+		return err
+	})
+}
+
+// StartTokenCleanup menghapus refresh token, isi denylist, dan sesi yang sudah tidak berlaku.
+func (h *Handler) StartTokenCleanup(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, "token cleanup", interval, func() error {
+		now := time.Now()
+		h.Denylist.Prune(now)
+		h.Sessions.Prune(now)
+		h.Accounts.Prune(now)
+
+		deleted, err := h.TodoRepository.DeleteExpiredRefreshTokens(now)
+		if deleted > 0 {
+			logrus.Infof("deleted %d expired refresh tokens", deleted)
+		}
+		if err != nil {
+			return err
+		}
+
+		deleted, err = h.TodoRepository.DeleteExpiredRevokedTokens(now)
+		if deleted > 0 {
+			logrus.Infof("deleted %d expired revoked tokens", deleted)
+		}
+		if err != nil {
+			return err
+		}
+
+		// sesi yang idle lebih lama dari umur refresh token tidak bisa dipakai lagi,
+		// sesi yang dicabut cukup disimpan sampai access token terakhirnya kadaluwarsa
+		deleted, err = h.TodoRepository.DeleteStaleSessions(now.Add(-cfg.RefreshTokenTTL()), now.Add(-cfg.AccessTokenTTL()))
+		if deleted > 0 {
+			logrus.Infof("deleted %d stale sessions", deleted)
+		}
+		return err
+	})
+}
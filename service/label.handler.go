@@ -0,0 +1,305 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) LabelHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	labels, err := h.repo(ctx).GetLabelsByUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get labels: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Labels",
+		Data:    labels,
+	})
+}
+
+func (h *Handler) LabelHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.LabelCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// nama label harus unik per user
+	existing, err := h.repo(ctx).GetLabelByName(userID, reqBody.Name)
+	if err != nil {
+		logrus.Errorf("failed when get label by name: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if existing != nil {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "Label already exist",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+
+	label := &entity.Label{
+		UserID: userID,
+		Name:   reqBody.Name,
+		Color:  reqBody.Color,
+	}
+	if err := h.repo(ctx).CreateLabel(label); err != nil {
+		logrus.Errorf("failed when creating label: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "New Label Created",
+		Data:    label,
+	})
+}
+
+func (h *Handler) LabelHandlerUpdate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	labelID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.LabelUpdateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	label, err := h.repo(ctx).GetLabelByID(labelID, userID)
+	if err != nil {
+		logrus.Errorf("failed when get label by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if label == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Label not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	if reqBody.Name != "" && reqBody.Name != label.Name {
+		existing, err := h.repo(ctx).GetLabelByName(userID, reqBody.Name)
+		if err != nil {
+			logrus.Errorf("failed when get label by name: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if existing != nil {
+			ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+				Message: "Label already exist",
+				Status:  http.StatusConflict,
+			})
+			return
+		}
+	}
+
+	updates := reqBody.ReqLabel()
+	if len(updates) == 0 {
+		ctx.AbortWithStatusJSON(http.StatusOK, request.TodoIDResponse{
+			Message: "Not Change",
+			Data:    label,
+		})
+		return
+	}
+	if err := h.repo(ctx).UpdateLabel(labelID, userID, updates); err != nil {
+		logrus.Errorf("failed when updating label: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Label",
+		Data:    reqBody,
+	})
+}
+
+func (h *Handler) LabelHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	labelID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isDeleted, err := h.repo(ctx).DeleteLabel(labelID, userID)
+	if err != nil {
+		logrus.Errorf("failed when deleting label: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Label not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Delete Label",
+	})
+}
+
+// TodoLabelHandlerAttach menempelkan label milik user ke todo miliknya.
+func (h *Handler) TodoLabelHandlerAttach(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	labelID, ok := paramID(ctx, "label_id")
+	if !ok {
+		return
+	}
+
+	if !h.ensureTodoAndLabel(ctx, todoID, labelID, userID) {
+		return
+	}
+
+	if err := h.repo(ctx).AttachLabel(todoID, labelID); err != nil {
+		logrus.Errorf("failed when attaching label: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryLabelAttached, nil, gin.H{"label_id": labelID})
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Label attached",
+		Data:    gin.H{"todo_id": todoID, "label_id": labelID},
+	})
+}
+
+func (h *Handler) TodoLabelHandlerDetach(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	labelID, ok := paramID(ctx, "label_id")
+	if !ok {
+		return
+	}
+
+	if !h.ensureTodoAndLabel(ctx, todoID, labelID, userID) {
+		return
+	}
+
+	isDetached, err := h.repo(ctx).DetachLabel(todoID, labelID)
+	if err != nil {
+		logrus.Errorf("failed when detaching label: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDetached == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Label is not attached to this todo",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryLabelDetached, gin.H{"label_id": labelID}, nil)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Label detached",
+	})
+}
+
+// ensureTodoAndLabel memastikan todo dan label sama-sama milik user.
+func (h *Handler) ensureTodoAndLabel(ctx *gin.Context, todoID, labelID, userID int64) bool {
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return false
+	}
+
+	label, err := h.repo(ctx).GetLabelByID(labelID, userID)
+	if err != nil {
+		logrus.Errorf("failed when get label by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return false
+	}
+	if label == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Label not found",
+			Status:  http.StatusNotFound,
+		})
+		return false
+	}
+
+	return true
+}
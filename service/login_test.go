@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func login(t *testing.T, repo *mocks.TodoRepository, password string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/login", handler.Login)
+
+	body := `{"username": "dina", "password": "` + password + `"}`
+	req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoginAccountStatus(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("benar123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	disabledAt := time.Now()
+
+	t.Run("Active", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetUserByUsername", "dina").Return(&entity.User{Id: 7, Username: "dina", Password: string(hash)}, nil)
+		var sessionID string
+		repo.On("CreateSession", mock.MatchedBy(func(session *entity.Session) bool {
+			sessionID = session.ID
+			return session.UserID == 7 && session.ID != ""
+		})).Return(nil)
+		// refresh token memakai id sesi sebagai family
+		repo.On("CreateRefreshToken", mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			return token.UserID == 7 && token.FamilyID == sessionID
+		})).Return(nil)
+		repo.On("GetAllUserByID", int64(7), mock.Anything).Return([]entity.Todolist{}, nil)
+
+		w := login(t, repo, "benar123")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token"`)
+		assert.Contains(t, w.Body.String(), `"refresh_token"`)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetUserByUsername", "dina").Return(&entity.User{Id: 7, Username: "dina", Password: string(hash), DisabledAt: &disabledAt}, nil)
+
+		w := login(t, repo, "benar123")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "Account is disabled")
+		assert.NotContains(t, w.Body.String(), "token")
+	})
+
+	t.Run("DisabledWrongPassword", func(t *testing.T) {
+		// status akun baru terlihat setelah password benar
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetUserByUsername", "dina").Return(&entity.User{Id: 7, Username: "dina", Password: string(hash), DisabledAt: &disabledAt}, nil)
+
+		w := login(t, repo, "salah")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotContains(t, w.Body.String(), "disabled")
+	})
+
+	t.Run("PasswordResetRequired", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetUserByUsername", "dina").Return(&entity.User{Id: 7, Username: "dina", Password: string(hash), PasswordResetRequired: true}, nil)
+
+		w := login(t, repo, "benar123")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "/password-reset")
+	})
+
+	t.Run("SecondFactorRequired", func(t *testing.T) {
+		user := totpUser()
+		user.Password = string(hash)
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetUserByUsername", "dina").Return(user, nil)
+
+		// password benar belum cukup, sesi baru dibuat setelah kode 2FA
+		w := login(t, repo, "benar123")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"mfa_required":true`)
+		assert.NotContains(t, w.Body.String(), `"refresh_token"`)
+		repo.AssertNumberOfCalls(t, "CreateSession", 0)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"todoGin/mocks"
 	"todoGin/model/entity"
@@ -23,6 +24,12 @@ import (
 //
 // golangci-lint run --timeout=5m --fix ./...
 
+// withUser menggantikan Authmiddleware di test: user_id selalu 1
+func withUser(ctx *gin.Context) {
+	ctx.Set("user_id", int64(1))
+	ctx.Next()
+}
+
 func TestTodolist(t *testing.T) {
 	t.Run("TestGetAll", TestGetAll)
 	t.Run("TestCreate", TestCreate)
@@ -36,13 +43,13 @@ func TestGetAll(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockTodo := []entity.Todolist{
-			{ID: 1, Title: "Task 1", Status: false},
-			{ID: 2, Title: "Task 2", Status: false},
+			{ID: 1, Title: "Task 1", Status: entity.StatusTodo},
+			{ID: 2, Title: "Task 2", Status: entity.StatusTodo},
 		}
 
 		// success
 		repo := mocks.NewTodoRepository(t)
-		repo.On("GetAll").Return(mockTodo, nil)
+		repo.On("GetAllUserByID", int64(1), mock.Anything).Return(mockTodo, nil)
 
 		handler := NewTodoService(repo)
 
@@ -53,7 +60,7 @@ func TestGetAll(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 		router := gin.Default()
-		router.GET("/manage-todos", handler.TodolistHandlerGetAll)
+		router.GET("/manage-todos", withUser, handler.TodolistHandlerGetAll)
 		router.ServeHTTP(rr, req)
 
 		var resp request.TodoResponseToGetAll
@@ -71,7 +78,7 @@ func TestGetAll(t *testing.T) {
 	// Internal Server Error
 	t.Run("Internal Server Error", func(t *testing.T) {
 		repo := mocks.NewTodoRepository(t)
-		repo.On("GetAll").Return(nil, errors.New("some error"))
+		repo.On("GetAllUserByID", int64(1), mock.Anything).Return(nil, errors.New("some error"))
 
 		handler := NewTodoService(repo)
 
@@ -82,7 +89,7 @@ func TestGetAll(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 		router := gin.Default()
-		router.GET("/manage-todos", handler.TodolistHandlerGetAll)
+		router.GET("/manage-todos", withUser, handler.TodolistHandlerGetAll)
 		router.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
@@ -99,7 +106,7 @@ func TestGetAll(t *testing.T) {
 
 	t.Run("Empty", func(t *testing.T) {
 		repo := mocks.NewTodoRepository(t)
-		repo.On("GetAll").Return([]entity.Todolist{}, nil)
+		repo.On("GetAllUserByID", int64(1), mock.Anything).Return([]entity.Todolist{}, nil)
 
 		handler := NewTodoService(repo)
 
@@ -110,7 +117,7 @@ func TestGetAll(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 		router := gin.Default()
-		router.GET("/manage-todos", handler.TodolistHandlerGetAll)
+		router.GET("/manage-todos", withUser, handler.TodolistHandlerGetAll)
 		router.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
@@ -138,10 +145,13 @@ func TestCreate(t *testing.T) {
 		newTodo := &entity.Todolist{
 			//ID:     1,
 			Title:  "Makan",
-			Status: false,
+			Status: entity.StatusTodo,
 		}
 
-		todoRepo.On("Create", "Makan").Return(newTodo, nil)
+		todoRepo.On("Create", mock.MatchedBy(func(todo *entity.Todolist) bool {
+			return todo.Title == "Makan" && todo.UserID == 1
+		})).Return(newTodo, nil)
+		todoRepo.On("AddHistory", mock.Anything).Return(nil)
 
 		// Initialize todo service with mock repository
 		handler := NewTodoService(todoRepo)
@@ -149,7 +159,7 @@ func TestCreate(t *testing.T) {
 		// Call the create endpoint
 		endpoint := "/manage-todo"
 		r := gin.New()
-		r.POST(endpoint, handler.TodolistHandlerCreate)
+		r.POST(endpoint, withUser, handler.TodolistHandlerCreate)
 
 		// Create an HTTP request to create a new Todo
 		reqBody := bytes.NewBufferString(`{"title": "Makan"}`)
@@ -198,7 +208,7 @@ func TestCreate(t *testing.T) {
 		// Set up Gin context
 		w := httptest.NewRecorder()
 		c, r := gin.CreateTestContext(w)
-		r.POST(endpoint, func(context *gin.Context) {
+		r.POST(endpoint, withUser, func(context *gin.Context) {
 			handler.TodolistHandlerCreate(context)
 		})
 
@@ -232,7 +242,7 @@ func TestCreate(t *testing.T) {
 		expectedError := errors.New("Internal Server Error")
 		endpoint := "/manage-todo"
 
-		todoRepo.On("Create", "Test Todo").Return(nil, expectedError)
+		todoRepo.On("Create", mock.Anything).Return(nil, expectedError)
 
 		// Create valid input
 		body := bytes.NewBufferString(`{"title": "Test Todo"}`)
@@ -242,7 +252,7 @@ func TestCreate(t *testing.T) {
 		// Set up Gin context
 		w := httptest.NewRecorder()
 		c, r := gin.CreateTestContext(w)
-		r.POST(endpoint, handler.TodolistHandlerCreate)
+		r.POST(endpoint, withUser, handler.TodolistHandlerCreate)
 
 		// Perform request
 		c.Request = req
@@ -260,7 +270,7 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, expectedError.Error(), errResp.Message)
 
 		// Check mock call
-		todoRepo.AssertCalled(t, "Create", "Test Todo")
+		todoRepo.AssertCalled(t, "Create", mock.Anything)
 	})
 
 }
@@ -285,10 +295,11 @@ func TestUpdate(t *testing.T) {
 		expectedTodo := entity.Todolist{
 			ID:     1,
 			Title:  "New Title",
-			Status: false,
+			Status: entity.StatusTodo,
 		}
-		mockRepo.On("GetByID", int64(1)).Return(&entity.Todolist{}, nil)
-		mockRepo.On("Update", int64(1), mock.Anything).Return(&expectedTodo, nil)
+		mockRepo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		mockRepo.On("Update", int64(1), int64(1), mock.Anything).Return(&expectedTodo, nil)
+		mockRepo.On("AddHistory", mock.Anything).Return(nil)
 
 		// create test request
 		req, _ := http.NewRequest(http.MethodPut, "/manage-todo/todo/1", bytes.NewBuffer(requestBodyBytes))
@@ -296,7 +307,7 @@ func TestUpdate(t *testing.T) {
 
 		// perform test request
 		r := gin.Default()
-		r.PUT("/manage-todo/todo/:id", handler.TodolistHandlerUpdate)
+		r.PUT("/manage-todo/todo/:id", withUser, handler.TodolistHandlerUpdate)
 		r.ServeHTTP(rr, req)
 
 		// check response
@@ -321,7 +332,7 @@ func TestUpdate(t *testing.T) {
 		requestBodyBytes, _ := json.Marshal(reqBody1)
 
 		// create mock behavior
-		mockRepo.On("GetByID", int64(2)).Return(nil, nil)
+		mockRepo.On("GetByID", int64(2), int64(1)).Return(nil, nil)
 
 		// create test request
 		req, _ := http.NewRequest(http.MethodPut, "/manage-todo/todo/2", bytes.NewBuffer(requestBodyBytes))
@@ -329,7 +340,7 @@ func TestUpdate(t *testing.T) {
 
 		// perform test request
 		r := gin.Default()
-		r.PUT("/manage-todo/todo/:id", handler.TodolistHandlerUpdate)
+		r.PUT("/manage-todo/todo/:id", withUser, handler.TodolistHandlerUpdate)
 		r.ServeHTTP(rr, req)
 
 		// check response
@@ -351,15 +362,14 @@ func TestUpdate(t *testing.T) {
 		// membuat object handler dan menambahkan dependensi mock
 		handler := NewTodoService(mockRepo)
 
-		mockRepo.On("GetByID", int64(3)).Return(&entity.Todolist{}, nil)
-		mockRepo.On("Update", int64(3), mock.Anything).Return(nil, errors.New("Internal Server Error"))
+		mockRepo.On("GetByID", int64(3), int64(1)).Return(&entity.Todolist{UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		mockRepo.On("Update", int64(3), int64(1), mock.Anything).Return(nil, errors.New("Internal Server Error"))
 
 		// membuat handler dengan mock object
 
 		// membuat request payload
 		payload := request.TodolistUpdateRequest{
-			Title:  "New Title",
-			Status: false,
+			Title: "New Title",
 		}
 		requestBody, _ := json.Marshal(payload)
 
@@ -369,7 +379,7 @@ func TestUpdate(t *testing.T) {
 
 		// perform test request
 		r := gin.Default()
-		r.PUT("/manage-todo/todo/:id", handler.TodolistHandlerUpdate)
+		r.PUT("/manage-todo/todo/:id", withUser, handler.TodolistHandlerUpdate)
 		r.ServeHTTP(rr, req)
 
 		// melakukan pengecekan status code dan response
@@ -397,12 +407,12 @@ func TestGetByID(t *testing.T) {
 		handler := NewTodoService(mockTodoRepo)
 
 		// testing success
-		mockTodoRepo.On("GetByID", int64(1)).Return(&entity.Todolist{ID: 1, Title: "Test Todo"}, nil)
+		mockTodoRepo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Title: "Test Todo", Description: "**penting**"}, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/manage-todo/todo/1", nil)
 		router := gin.Default()
-		router.GET("/manage-todo/todo/:id", handler.TodolistHandlerGetByID)
+		router.GET("/manage-todo/todo/:id", withUser, handler.TodolistHandlerGetByID)
 		router.ServeHTTP(w, req)
 
 		respBody, err := io.ReadAll(w.Body)
@@ -416,6 +426,9 @@ func TestGetByID(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, response.Message, "Success Get Id")
+		// description_html hanya ada di dalam data, dari Todolist.MarshalJSON
+		assert.Equal(t, 1, strings.Count(string(respBody), `"description_html"`))
+		assert.Contains(t, string(respBody), `\u003cstrong\u003epenting`)
 	})
 
 	// testing not found
@@ -425,12 +438,12 @@ func TestGetByID(t *testing.T) {
 		// inisiasi handler
 		handler := NewTodoService(mockTodoRepo)
 
-		mockTodoRepo.On("GetByID", int64(2)).Return(nil, nil)
+		mockTodoRepo.On("GetByID", int64(2), int64(1)).Return(nil, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/manage-todo/todo/2", nil)
 		router := gin.Default()
-		router.GET("/manage-todo/todo/:id", handler.TodolistHandlerGetByID)
+		router.GET("/manage-todo/todo/:id", withUser, handler.TodolistHandlerGetByID)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
@@ -449,12 +462,12 @@ func TestGetByID(t *testing.T) {
 		// inisiasi handler
 		handler := NewTodoService(mockTodoRepo)
 
-		mockTodoRepo.On("GetByID", int64(3)).Return(nil, errors.New("Internal Server Error"))
+		mockTodoRepo.On("GetByID", int64(3), int64(1)).Return(nil, errors.New("Internal Server Error"))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/manage-todo/todo/3", nil)
 		router := gin.Default()
-		router.GET("/manage-todo/todo/:id", handler.TodolistHandlerGetByID)
+		router.GET("/manage-todo/todo/:id", withUser, handler.TodolistHandlerGetByID)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -475,12 +488,14 @@ func TestDelete(t *testing.T) {
 		handler := NewTodoService(mockTodoRepo)
 
 		// Testing Success
-		mockTodoRepo.On("Delete", int64(1)).Return(int64(1), nil)
+		mockTodoRepo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		mockTodoRepo.On("Delete", int64(1), int64(1)).Return(int64(1), nil)
+		mockTodoRepo.On("AddHistory", mock.Anything).Return(nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, "/manage-todo/todo/1", nil)
 		router := gin.Default()
-		router.DELETE("/manage-todo/todo/:id", handler.TodolistHandlerDelete)
+		router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -498,11 +513,11 @@ func TestDelete(t *testing.T) {
 		mockTodoRepo := mocks.NewTodoRepository(t)
 		handler := NewTodoService(mockTodoRepo)
 
-		mockTodoRepo.On("Delete", int64(2)).Return(int64(0), nil)
+		mockTodoRepo.On("GetByID", int64(2), int64(1)).Return(nil, nil)
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, "/manage-todo/todo/2", nil)
 		router := gin.Default()
-		router.DELETE("/manage-todo/todo/:id", handler.TodolistHandlerDelete)
+		router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
@@ -519,12 +534,13 @@ func TestDelete(t *testing.T) {
 		mockTodoRepo := mocks.NewTodoRepository(t)
 		handler := NewTodoService(mockTodoRepo)
 
-		mockTodoRepo.On("Delete", int64(3)).Return(int64(0), errors.New("Internal Server Error"))
+		mockTodoRepo.On("GetByID", int64(3), int64(1)).Return(&entity.Todolist{ID: 3, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		mockTodoRepo.On("Delete", int64(3), int64(1)).Return(int64(0), errors.New("Internal Server Error"))
 		w := httptest.NewRecorder()
 
 		req, _ := http.NewRequest(http.MethodDelete, "/manage-todo/todo/3", nil)
 		router := gin.Default()
-		router.DELETE("/manage-todo/todo/:id", handler.TodolistHandlerDelete)
+		router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// ResetPassword mengganti password tanpa token, dipakai juga setelah admin memaksa reset password.
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	reqBody := new(request.PasswordResetRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.Error{
+			Error: "invalid request Body",
+		})
+		return
+	}
+
+	storedUser, err := h.TodoRepository.GetUserByUsername(reqBody.Username)
+	if err != nil || storedUser == nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid Username or Password",
+		})
+		return
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(storedUser.Password), []byte(reqBody.Password))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid Username or Password",
+		})
+		return
+	}
+
+	if storedUser.DisabledAt != nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.Error{
+			Error: "Account is disabled",
+		})
+		return
+	}
+
+	// user dengan 2FA aktif juga harus mengirim kode TOTP atau recovery code
+	if storedUser.TOTPEnabledAt != nil {
+		valid, err := h.verifySecondFactor(storedUser, reqBody.Code)
+		if errors.Is(err, errMFALocked) {
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, respErr.Error{
+				Error: errMFALocked.Error(),
+			})
+			return
+		}
+		if err != nil {
+			logrus.Errorf("failed when verifying second factor: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+				Error: "Failed Update Password",
+			})
+			return
+		}
+		if !valid {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+				Error: "invalid 2FA code",
+			})
+			return
+		}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(reqBody.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed hash Password",
+		})
+		return
+	}
+
+	if _, err := h.TodoRepository.UpdatePassword(storedUser.Id, string(hashedPassword), false); err != nil {
+		logrus.Errorf("failed when updating password: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed Update Password",
+		})
+		return
+	}
+	h.Accounts.Forget(storedUser.Id)
+
+	// semua sesi lama dicabut setelah password diganti
+	if _, err := h.revokeUserSessions(storedUser.Id, ""); err != nil {
+		logrus.Errorf("failed when revoking sessions: %v", err)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password updated, please login again"})
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) ProjectHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	includeArchived := ctx.Query("archived") == "true"
+	projects, err := h.repo(ctx).GetProjectsByUser(userID, includeArchived)
+	if err != nil {
+		logrus.Errorf("failed when get projects: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Projects",
+		Data:    projects,
+	})
+}
+
+func (h *Handler) ProjectHandlerGetByID(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	project, ok := h.loadProject(ctx, projectID, userID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Project",
+		Data:    project,
+	})
+}
+
+func (h *Handler) ProjectHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.ProjectCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	project := &entity.Project{
+		UserID:    userID,
+		Name:      reqBody.Name,
+		Color:     reqBody.Color,
+		SortOrder: reqBody.SortOrder,
+	}
+	if err := h.repo(ctx).CreateProject(project); err != nil {
+		logrus.Errorf("failed when creating project: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "New Project Created",
+		Data:    project,
+	})
+}
+
+func (h *Handler) ProjectHandlerUpdate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.ProjectUpdateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	project, ok := h.loadProject(ctx, projectID, userID)
+	if !ok {
+		return
+	}
+
+	updates := reqBody.ReqProject()
+	if len(updates) == 0 {
+		ctx.AbortWithStatusJSON(http.StatusOK, request.TodoIDResponse{
+			Message: "Not Change",
+			Data:    project,
+		})
+		return
+	}
+	if err := h.repo(ctx).UpdateProject(projectID, userID, updates); err != nil {
+		logrus.Errorf("failed when updating project: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Project",
+		Data:    reqBody,
+	})
+}
+
+func (h *Handler) ProjectHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isDeleted, err := h.repo(ctx).DeleteProject(projectID, userID)
+	if err != nil {
+		logrus.Errorf("failed when deleting project: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Project not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Delete Project",
+	})
+}
+
+// TodoMoveHandler memindahkan todo ke project lain atau mengeluarkannya dari project.
+func (h *Handler) TodoMoveHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TodoMoveRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	todo, ok := h.loadOwnTodo(ctx, todoID, userID)
+	if !ok {
+		return
+	}
+	if reqBody.ProjectID != nil {
+		if _, ok := h.loadProject(ctx, *reqBody.ProjectID, userID); !ok {
+			return
+		}
+	}
+
+	_, err := h.repo(ctx).Update(todoID, todo.UserID, map[string]interface{}{"project_id": reqBody.ProjectID})
+	if err != nil {
+		logrus.Errorf("failed when moving todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryProjectMoved,
+		gin.H{"project_id": todo.ProjectID}, gin.H{"project_id": reqBody.ProjectID})
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Todo moved",
+		Data:    gin.H{"todo_id": todoID, "project_id": reqBody.ProjectID},
+	})
+}
+
+// loadProject mengambil project milik user, request di-abort kalau tidak ditemukan.
+func (h *Handler) loadProject(ctx *gin.Context, projectID, userID int64) (*entity.Project, bool) {
+	project, err := h.repo(ctx).GetProjectByID(projectID, userID)
+	if err != nil {
+		logrus.Errorf("failed when get project by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	if project == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Project not found",
+			Status:  http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return project, true
+}
+
+// loadOwnProject seperti loadProject tapi hanya untuk pembuat project, dipakai untuk share project.
+func (h *Handler) loadOwnProject(ctx *gin.Context, projectID, userID int64) (*entity.Project, bool) {
+	project, ok := h.loadProject(ctx, projectID, userID)
+	if !ok {
+		return nil, false
+	}
+	if project.UserID != userID {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the owner can do this",
+			Status:  http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return project, true
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+)
+
+func TestListFilteredByProject(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetAllUserByID", int64(1), mock.MatchedBy(func(filter request.TodoFilter) bool {
+		return filter.ProjectID != nil && *filter.ProjectID == 3
+	})).Return([]entity.Todolist{{ID: 1}}, nil)
+
+	w := getTodos(t, repo, "/manage-todos?project_id=3")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = getTodos(t, mocks.NewTodoRepository(t), "/manage-todos?project_id=inbox")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateTodoInForeignProject(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	// project 9 bukan milik user 1
+	repo.On("GetProjectByID", int64(9), int64(1)).Return(nil, nil)
+
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/manage-todo", withUser, handler.TodolistHandlerCreate)
+
+	req, err := http.NewRequest(http.MethodPost, "/manage-todo", bytes.NewBufferString(`{"title": "Beli cat", "project_id": 9}`))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	repo.AssertNumberOfCalls(t, "Create", 0)
+}
+
+func moveTodo(t *testing.T, repo *mocks.TodoRepository, body string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id/project", withUser, handler.TodoMoveHandler)
+
+	req, err := http.NewRequest(http.MethodPut, "/manage-todo/todo/1/project", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTodoMove(t *testing.T) {
+	t.Run("IntoOwnProject", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetProjectByID", int64(3), int64(1)).Return(&entity.Project{ID: 3, UserID: 1}, nil)
+		repo.On("Update", int64(1), int64(1), mock.MatchedBy(func(updates map[string]interface{}) bool {
+			projectID, ok := updates["project_id"].(*int64)
+			return ok && projectID != nil && *projectID == 3
+		})).Return(&entity.Todolist{ID: 1}, nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := moveTodo(t, repo, `{"project_id": 3}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OutOfProject", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("Update", int64(1), int64(1), mock.MatchedBy(func(updates map[string]interface{}) bool {
+			projectID, ok := updates["project_id"].(*int64)
+			return ok && projectID == nil
+		})).Return(&entity.Todolist{ID: 1}, nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := moveTodo(t, repo, `{"project_id": null}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		repo.AssertNumberOfCalls(t, "GetProjectByID", 0)
+	})
+
+	t.Run("IntoForeignProject", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetProjectByID", int64(9), int64(1)).Return(nil, nil)
+
+		w := moveTodo(t, repo, `{"project_id": 9}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		repo.AssertNumberOfCalls(t, "Update", 0)
+	})
+}
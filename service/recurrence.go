@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"github.com/teambition/rrule-go"
+	"strings"
+	"time"
+)
+
+// normalizeRecurrence memvalidasi RRULE (tanpa DTSTART) dan mengembalikan bentuk bakunya.
+func normalizeRecurrence(recurrence string) (string, error) {
+	recurrence = strings.TrimPrefix(strings.TrimSpace(recurrence), "RRULE:")
+	if recurrence == "" {
+		return "", nil
+	}
+	if strings.Contains(recurrence, "\n") || strings.Contains(recurrence, "DTSTART") {
+		return "", errors.New("recurrence must be a single RRULE without DTSTART, the due date is used as start")
+	}
+
+	option, err := rrule.StrToROption(recurrence)
+	if err != nil {
+		return "", err
+	}
+	if _, err := rrule.NewRRule(*option); err != nil {
+		return "", err
+	}
+
+	return option.RRuleString(), nil
+}
+
+// recurrenceRule membangun rule dengan due date todo sebagai DTSTART.
+func recurrenceRule(recurrence string, dueAt time.Time) (*rrule.RRule, *rrule.ROption, error) {
+	option, err := rrule.StrToROptionInLocation(recurrence, dueAt.Location())
+	if err != nil {
+		return nil, nil, err
+	}
+	option.Dtstart = dueAt
+
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rule, option, nil
+}
+
+// nextOccurrence mengembalikan due date berikutnya setelah dueAt beserta RRULE
+// untuk instance berikutnya (COUNT dikurangi satu). ok bernilai false kalau seri sudah habis.
+func nextOccurrence(recurrence string, dueAt time.Time) (next time.Time, nextRecurrence string, ok bool, err error) {
+	rule, option, err := recurrenceRule(recurrence, dueAt)
+	if err != nil {
+		return time.Time{}, "", false, err
+	}
+
+	// COUNT menghitung dueAt sendiri, jadi COUNT=1 berarti ini kejadian terakhir
+	if option.Count == 1 {
+		return time.Time{}, "", false, nil
+	}
+
+	next = rule.After(dueAt, false)
+	if next.IsZero() {
+		return time.Time{}, "", false, nil
+	}
+
+	if option.Count > 1 {
+		option.Count--
+	}
+	option.Dtstart = time.Time{}
+	return next, option.RRuleString(), true, nil
+}
+
+// previewOccurrences mengembalikan maksimal n kejadian mulai dari dueAt.
+func previewOccurrences(recurrence string, dueAt time.Time, n int) ([]time.Time, error) {
+	rule, _, err := recurrenceRule(recurrence, dueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]time.Time, 0, n)
+	iterator := rule.Iterator()
+	for len(occurrences) < n {
+		occurrence, ok := iterator()
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func TestNormalizeRecurrence(t *testing.T) {
+	valid := map[string]string{
+		"":                                   "",
+		"FREQ=DAILY":                         "FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE":      "FREQ=WEEKLY;BYDAY=MO,WE",
+		"  FREQ=MONTHLY;COUNT=3  ":           "FREQ=MONTHLY;COUNT=3",
+		"FREQ=YEARLY;UNTIL=20301231T000000Z": "FREQ=YEARLY;UNTIL=20301231T000000Z",
+	}
+	for input, want := range valid {
+		got, err := normalizeRecurrence(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, want, got, input)
+		}
+	}
+
+	// DTSTART tidak boleh dikirim, due_at yang dipakai sebagai awal seri
+	for _, input := range []string{
+		"DTSTART:20261017T090000Z\nRRULE:FREQ=DAILY",
+		"FREQ=SOMETIMES",
+		"every monday",
+	} {
+		_, err := normalizeRecurrence(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNextOccurrenceCountSeries(t *testing.T) {
+	// seri mingguan 3 kali: due_at pertama ikut dihitung, jadi hanya ada 2 instance lanjutan
+	due := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	recurrence := "FREQ=WEEKLY;COUNT=3"
+
+	var dues []time.Time
+	for {
+		next, nextRecurrence, ok, err := nextOccurrence(recurrence, due)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		require.Less(t, len(dues), 3, "series never ends")
+		dues = append(dues, next)
+		due, recurrence = next, nextRecurrence
+	}
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC),
+	}, dues)
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=1", recurrence)
+}
+
+func TestNextOccurrenceUntilPassed(t *testing.T) {
+	due := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	_, _, ok, err := nextOccurrence("FREQ=DAILY;UNTIL=20261017T235959Z", due)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNextOccurrenceKeepsLocalTime(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	due := time.Date(2026, 10, 17, 9, 0, 0, 0, jakarta)
+
+	next, _, ok, err := nextOccurrence("FREQ=DAILY;INTERVAL=2", due)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, next.Equal(time.Date(2026, 10, 19, 9, 0, 0, 0, jakarta)), "got %v", next)
+}
+
+func TestCompleteRecurringTodo(t *testing.T) {
+	due := time.Date(2026, 10, 17, 17, 0, 0, 0, time.UTC)
+	start := due.Add(-2 * time.Hour)
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{
+		ID:         1,
+		Title:      "Laporan harian",
+		Status:     entity.StatusInProgress,
+		StartAt:    &start,
+		DueAt:      &due,
+		Recurrence: "FREQ=DAILY;COUNT=5",
+		UserID:     1,
+		AccessRole: entity.AccessOwner,
+	}, nil)
+	// instance berikutnya dibuat di transaksi yang sama dengan perubahan status
+	repo.On("ChangeStatus", int64(1), entity.StatusInProgress, entity.StatusDone, mock.Anything, mock.MatchedBy(func(next *entity.Todolist) bool {
+		// jarak start_at ke due_at tetap 2 jam dan COUNT berkurang satu
+		return next.Title == "Laporan harian" &&
+			next.DueAt.Equal(due.AddDate(0, 0, 1)) &&
+			next.StartAt != nil && next.StartAt.Equal(due.AddDate(0, 0, 1).Add(-2*time.Hour)) &&
+			next.Recurrence == "FREQ=DAILY;COUNT=4" &&
+			next.UserID == 1
+	})).Return(&entity.StatusTransition{TodoID: 1}, nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"status": "done"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCreateRecurrenceRequiresDueAt(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/manage-todo", withUser, handler.TodolistHandlerCreate)
+
+	req, err := http.NewRequest(http.MethodPost, "/manage-todo",
+		bytes.NewBufferString(`{"title": "Olahraga", "recurrence": "FREQ=DAILY"}`))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	repo.AssertNumberOfCalls(t, "Create", 0)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func reorderTodo(t *testing.T, repo *mocks.TodoRepository, body string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id/position", withUser, handler.TodolistReorderHandler)
+
+	req, err := http.NewRequest(http.MethodPut, "/manage-todo/todo/1/position", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestReorderTodo(t *testing.T) {
+	t.Run("AfterTarget", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		histories := captureHistory(repo)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, Position: "G", AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("MoveTodo", int64(1), int64(1), int64(2), true).Return("KV", nil)
+
+		w := reorderTodo(t, repo, `{"after_id": 2}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"position":"KV"`)
+		require.Len(t, *histories, 1)
+		assert.Equal(t, entity.HistoryReordered, (*histories)[0].Action)
+		assert.Equal(t, map[string]interface{}{"position": "G"}, decodeValues(t, (*histories)[0].Before))
+	})
+
+	t.Run("BeforeTarget", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("MoveTodo", int64(1), int64(1), int64(2), false).Return("F", nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := reorderTodo(t, repo, `{"before_id": 2}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("TargetMissing", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(99), int64(1)).Return(nil, nil)
+
+		w := reorderTodo(t, repo, `{"after_id": 99}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		repo.AssertNumberOfCalls(t, "MoveTodo", 0)
+	})
+
+	t.Run("TargetDeletedMeanwhile", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("MoveTodo", int64(1), int64(1), int64(2), true).Return("", gorm.ErrRecordNotFound)
+
+		w := reorderTodo(t, repo, `{"after_id": 2}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	// editor todo milik user 2 mengurutkan list milik user 2
+	t.Run("EditorOfSharedList", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 2, AccessRole: entity.ShareRoleEditor}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 2, AccessRole: entity.ShareRoleEditor}, nil)
+		repo.On("MoveTodo", int64(1), int64(2), int64(2), true).Return("M", nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := reorderTodo(t, repo, `{"after_id": 2}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ViewOnlyTarget", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 2, AccessRole: entity.ShareRoleEditor}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 2, AccessRole: entity.ShareRoleViewer}, nil)
+
+		w := reorderTodo(t, repo, `{"after_id": 2}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		repo.AssertNumberOfCalls(t, "MoveTodo", 0)
+	})
+
+	t.Run("DifferentLists", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 2, AccessRole: entity.ShareRoleEditor}, nil)
+
+		w := reorderTodo(t, repo, `{"after_id": 2}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		repo.AssertNumberOfCalls(t, "MoveTodo", 0)
+	})
+
+	// di workspace semua todo ada di satu list walaupun pembuatnya berbeda
+	t.Run("SameWorkspace", func(t *testing.T) {
+		workspaceID := int64(5)
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, WorkspaceID: &workspaceID, AccessRole: entity.AccessOwner}, nil)
+		repo.On("GetByID", int64(2), int64(1)).Return(&entity.Todolist{ID: 2, UserID: 3, WorkspaceID: &workspaceID, AccessRole: entity.ShareRoleEditor}, nil)
+		repo.On("MoveTodo", int64(1), int64(1), int64(2), false).Return("C", nil)
+		repo.On("AddHistory", mock.Anything).Return(nil)
+
+		w := reorderTodo(t, repo, `{"before_id": 2}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	// tepat satu anchor harus dikirim, dan anchor tidak boleh todo itu sendiri
+	for name, body := range map[string]string{
+		"NoAnchor":   `{}`,
+		"TwoAnchors": `{"before_id": 2, "after_id": 3}`,
+		"Itself":     `{"after_id": 1}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := reorderTodo(t, mocks.NewTodoRepository(t), body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
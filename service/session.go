@@ -0,0 +1,71 @@
+package service
+
+import (
+	"sync"
+	"time"
+	"todoGin/repository"
+)
+
+// SessionTracker mengecek apakah sesi dari claim sid masih aktif dan mencatat last_seen_at.
+// Sesi aktif di-cache selama touchInterval, jadi pencabutan dari instance lain
+// paling lambat berlaku setelah touchInterval.
+type SessionTracker struct {
+	repo          repository.TodoRepository
+	touchInterval time.Duration
+
+	mu     sync.Mutex
+	active map[string]time.Time // sid -> kapan last_seen_at diperbarui lagi
+}
+
+func NewSessionTracker(repo repository.TodoRepository, touchInterval time.Duration) *SessionTracker {
+	return &SessionTracker{
+		repo:          repo,
+		touchInterval: touchInterval,
+		active:        make(map[string]time.Time),
+	}
+}
+
+func (s *SessionTracker) IsActive(sessionID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	touchAt, cached := s.active[sessionID]
+	s.mu.Unlock()
+	if cached && now.Before(touchAt) {
+		return true, nil
+	}
+
+	active, err := s.repo.TouchSession(sessionID, now)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if active {
+		s.active[sessionID] = now.Add(s.touchInterval)
+	} else {
+		delete(s.active, sessionID)
+	}
+	return active, nil
+}
+
+// Forget dipanggil setelah sesi dicabut supaya cache tidak menganggapnya masih aktif.
+func (s *SessionTracker) Forget(sessionIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sessionID := range sessionIDs {
+		delete(s.active, sessionID)
+	}
+}
+
+// Prune membuang isi cache yang sudah lewat waktunya.
+func (s *SessionTracker) Prune(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sessionID, touchAt := range s.active {
+		if touchAt.Before(now) {
+			delete(s.active, sessionID)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) SessionHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.TodoRepository.GetActiveSessions(userID)
+	if err != nil {
+		logrus.Errorf("failed when get sessions: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	currentID := ctx.GetString("session_id")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Sessions",
+		Data:    sessions,
+	})
+}
+
+// SessionHandlerRevoke mencabut satu sesi, kalau sesi yang sedang dipakai artinya sama dengan logout.
+func (h *Handler) SessionHandlerRevoke(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	sessionID := ctx.Param("id")
+
+	isRevoked, err := h.TodoRepository.RevokeSession(sessionID, userID)
+	if err != nil {
+		logrus.Errorf("failed when revoking session: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isRevoked == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Session not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.Sessions.Forget(sessionID)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Session revoked",
+	})
+}
+
+// SessionHandlerRevokeOthers mencabut semua sesi kecuali sesi yang sedang dipakai.
+func (h *Handler) SessionHandlerRevokeOthers(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.revokeUserSessions(userID, ctx.GetString("session_id"))
+	if err != nil {
+		logrus.Errorf("failed when revoking sessions: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Other sessions revoked",
+		Data:    gin.H{"revoked": revoked},
+	})
+}
+
+// revokeUserSessions mencabut sesi user kecuali exceptSessionID (kosong berarti semua),
+// dipakai juga setelah password diganti atau akun dinonaktifkan.
+func (h *Handler) revokeUserSessions(userID int64, exceptSessionID string) (int64, error) {
+	sessions, err := h.TodoRepository.GetActiveSessions(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := h.TodoRepository.RevokeUserSessions(userID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, session := range sessions {
+		h.Sessions.Forget(session.ID)
+	}
+	return revoked, nil
+}
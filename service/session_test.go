@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"todoGin/mocks"
+)
+
+func TestSessionTrackerTouchesOncePerInterval(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	tracker := NewSessionTracker(repo, time.Minute)
+
+	// last_seen_at cukup diperbarui sekali per interval, bukan di setiap request
+	repo.On("TouchSession", "laptop", mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	for i := 0; i < 5; i++ {
+		active, err := tracker.IsActive("laptop")
+		require.NoError(t, err)
+		assert.True(t, active)
+	}
+
+	// setelah sesi dicabut di instance ini, request berikutnya langsung cek ke database
+	tracker.Forget("laptop")
+	repo.On("TouchSession", "laptop", mock.Anything).Return(false, nil).Once()
+	active, err := tracker.IsActive("laptop")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestSessionTrackerDoesNotCacheRevoked(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	tracker := NewSessionTracker(repo, time.Minute)
+
+	repo.On("TouchSession", "hp-hilang", mock.Anything).Return(false, nil).Twice()
+	for i := 0; i < 2; i++ {
+		active, err := tracker.IsActive("hp-hilang")
+		require.NoError(t, err)
+		assert.False(t, active)
+	}
+
+	dbErr := errors.New("database down")
+	repo.On("TouchSession", "tablet", mock.Anything).Return(false, dbErr).Once()
+	_, err := tracker.IsActive("tablet")
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotContains(t, tracker.active, "tablet")
+}
+
+func TestSessionTrackerPrune(t *testing.T) {
+	tracker := NewSessionTracker(mocks.NewTodoRepository(t), time.Minute)
+	now := time.Now()
+	tracker.active["lama"] = now.Add(-time.Second)
+	tracker.active["baru"] = now.Add(time.Minute)
+
+	tracker.Prune(now)
+
+	assert.NotContains(t, tracker.active, "lama")
+	assert.Contains(t, tracker.active, "baru")
+}
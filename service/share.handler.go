@@ -0,0 +1,360 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) TodoShareHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	shares, err := h.repo(ctx).GetTodoShares(todoID)
+	if err != nil {
+		logrus.Errorf("failed when get todo shares: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Shares",
+		Data:    shares,
+	})
+}
+
+func (h *Handler) TodoShareHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TodoShareRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	user, err := h.repo(ctx).GetUserByUsername(reqBody.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when get user by username: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if user.Id == userID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Cannot share a todo with yourself",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	share := &entity.TodoShare{
+		TodoID: todoID,
+		UserID: user.Id,
+		Role:   reqBody.Role,
+	}
+	if err := h.repo(ctx).ShareTodo(share); err != nil {
+		logrus.Errorf("failed when sharing todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	share.Username = user.Username
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Todo shared",
+		Data:    share,
+	})
+}
+
+// TodoShareHandlerDelete mencabut share. Owner bisa mencabut share siapa saja,
+// user yang dibagikan hanya bisa melepas share miliknya sendiri.
+func (h *Handler) TodoShareHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	sharedUserID, ok := paramID(ctx, "user_id")
+	if !ok {
+		return
+	}
+
+	todo, ok := h.loadTodo(ctx, todoID, userID)
+	if !ok {
+		return
+	}
+	if todo.AccessRole != entity.AccessOwner && sharedUserID != userID {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the owner can do this",
+			Status:  http.StatusForbidden,
+		})
+		return
+	}
+
+	isDeleted, err := h.repo(ctx).RemoveTodoShare(todoID, sharedUserID)
+	if err != nil {
+		logrus.Errorf("failed when removing todo share: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Share removed",
+	})
+}
+
+// SharedWithMeHandler menampilkan todo milik user lain yang dibagikan ke user ini.
+func (h *Handler) SharedWithMeHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	todos, err := h.repo(ctx).GetSharedWithUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get shared todos: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoResponseToGetAll{
+		Message: "Success Get Shared Todos",
+		UserId:  userID,
+		Data:    len(todos),
+		Todos:   todos,
+	})
+}
+
+func (h *Handler) ProjectShareHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnProject(ctx, projectID, userID); !ok {
+		return
+	}
+
+	shares, err := h.repo(ctx).GetProjectShares(projectID)
+	if err != nil {
+		logrus.Errorf("failed when get project shares: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Shares",
+		Data:    shares,
+	})
+}
+
+// ProjectShareHandlerCreate membagikan project beserta semua todo di dalamnya.
+func (h *Handler) ProjectShareHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TodoShareRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadOwnProject(ctx, projectID, userID); !ok {
+		return
+	}
+
+	user, err := h.repo(ctx).GetUserByUsername(reqBody.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when get user by username: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if user.Id == userID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Cannot share a project with yourself",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	share := &entity.ProjectShare{
+		ProjectID: projectID,
+		UserID:    user.Id,
+		Role:      reqBody.Role,
+	}
+	if err := h.repo(ctx).ShareProject(share); err != nil {
+		logrus.Errorf("failed when sharing project: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	share.Username = user.Username
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Project shared",
+		Data:    share,
+	})
+}
+
+// ProjectShareHandlerDelete mencabut share project. Owner bisa mencabut share siapa saja,
+// user yang dibagikan hanya bisa melepas share miliknya sendiri.
+func (h *Handler) ProjectShareHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	sharedUserID, ok := paramID(ctx, "user_id")
+	if !ok {
+		return
+	}
+
+	if sharedUserID != userID {
+		if _, ok := h.loadOwnProject(ctx, projectID, userID); !ok {
+			return
+		}
+	}
+
+	isDeleted, err := h.repo(ctx).RemoveProjectShare(projectID, sharedUserID)
+	if err != nil {
+		logrus.Errorf("failed when removing project share: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isDeleted == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Share removed",
+	})
+}
+
+// SharedProjectsWithMeHandler menampilkan project milik user lain yang dibagikan ke user ini.
+func (h *Handler) SharedProjectsWithMeHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	projects, err := h.repo(ctx).GetProjectsSharedWithUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get shared projects: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Shared Projects",
+		Data:    projects,
+	})
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"time"
+	"todoGin/cfg"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) ShareLinkHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.ShareLinkCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadOwnTodo(ctx, todoID, userID); !ok {
+		return
+	}
+
+	link := &entity.ShareLink{
+		TodoID: todoID,
+		UserID: userID,
+		// token memakai detik, jadi waktu kadaluwarsa dibulatkan ke detik
+		ExpiresAt: time.Now().Add(time.Duration(reqBody.ExpiresInHours) * time.Hour).Truncate(time.Second),
+	}
+	if reqBody.Password != "" {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(reqBody.Password), bcrypt.DefaultCost)
+		if err != nil {
+			logrus.Errorf("failed when hashing share link password: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		link.PasswordHash = string(hashedPassword)
+	}
+
+	if err := h.repo(ctx).CreateShareLink(link); err != nil {
+		logrus.Errorf("failed when creating share link: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	withShareToken(link)
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Share Link Created",
+		Data:    link,
+	})
+}
+
+// ShareLinkHandlerGetAll menampilkan share link user yang masih aktif.
+func (h *Handler) ShareLinkHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	links, err := h.repo(ctx).GetActiveShareLinks(userID, time.Now())
+	if err != nil {
+		logrus.Errorf("failed when get share links: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	for i := range links {
+		withShareToken(&links[i])
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Share Links",
+		Data:    links,
+	})
+}
+
+func (h *Handler) ShareLinkHandlerRevoke(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	linkID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isRevoked, err := h.repo(ctx).RevokeShareLink(linkID, userID)
+	if err != nil {
+		logrus.Errorf("failed when revoking share link: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isRevoked == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share link not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Share link revoked",
+	})
+}
+
+// PublicTodoHandler menampilkan todo lewat share link tanpa login. Kalau link
+// memakai password, password dikirim lewat header X-Share-Password.
+func (h *Handler) PublicTodoHandler(ctx *gin.Context) {
+	now := time.Now()
+	linkID, err := cfg.ParseShareLink(ctx.Param("token"), now)
+	if errors.Is(err, cfg.ErrShareLinkExpired) {
+		ctx.AbortWithStatusJSON(http.StatusGone, respErr.ErrorResponse{
+			Message: "Share link expired",
+			Status:  http.StatusGone,
+		})
+		return
+	}
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share link not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	link, err := h.repo(ctx).GetShareLink(linkID)
+	if err != nil {
+		logrus.Errorf("failed when get share link: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if link == nil || link.RevokedAt != nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share link not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if !now.Before(link.ExpiresAt) {
+		ctx.AbortWithStatusJSON(http.StatusGone, respErr.ErrorResponse{
+			Message: "Share link expired",
+			Status:  http.StatusGone,
+		})
+		return
+	}
+
+	if link.PasswordHash != "" {
+		// password hanya lewat header supaya tidak tercatat di log akses atau history browser
+		password := ctx.GetHeader("X-Share-Password")
+		if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.ErrorResponse{
+				Message: "Invalid share link password",
+				Status:  http.StatusUnauthorized,
+			})
+			return
+		}
+	}
+
+	todo, err := h.repo(ctx).GetPublicTodo(link.TodoID)
+	if err != nil {
+		logrus.Errorf("failed when get public todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if todo == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Share link not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Shared Todo",
+		Data: request.PublicTodo{
+			Title:           todo.Title,
+			Description:     todo.Description,
+			DescriptionHTML: entity.RenderDescription(todo.Description),
+			Status:          todo.Status,
+			StartAt:         todo.StartAt,
+			DueAt:           todo.DueAt,
+			Priority:        todo.Priority,
+			Attachments:     todo.Attachments,
+			ChecklistItems:  todo.ChecklistItems,
+			Progress:        todo.Progress,
+		},
+	})
+}
+
+func withShareToken(link *entity.ShareLink) {
+	link.Token = cfg.SignShareLink(link.ID, link.ExpiresAt)
+	link.HasPassword = link.PasswordHash != ""
+}
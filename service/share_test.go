@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+// sharedTodo adalah todo milik user 2 yang dibagikan ke user 1 dengan role tertentu.
+func sharedTodo(role string) *entity.Todolist {
+	return &entity.Todolist{ID: 1, UserID: 2, Title: "Rencana rilis", Status: entity.StatusTodo, AccessRole: role}
+}
+
+func TestViewerCannotChangeTodo(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(sharedTodo(entity.ShareRoleViewer), nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Diubah viewer"}`)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "Update", 0)
+}
+
+func TestEditorUpdatesOwnersTodo(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(sharedTodo(entity.ShareRoleEditor), nil)
+	// baris todo tetap milik owner (user 2), history mencatat editornya
+	repo.On("Update", int64(1), int64(2), mock.Anything).Return(&entity.Todolist{ID: 1, UserID: 2}, nil)
+	histories := captureHistory(repo)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Diubah editor"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, *histories, 1)
+	assert.Equal(t, int64(1), (*histories)[0].UserID)
+}
+
+func TestEditorCannotDeleteOrShare(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(sharedTodo(entity.ShareRoleEditor), nil)
+
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
+	router.POST("/manage-todo/todo/:id/shares", withUser, handler.TodoShareHandlerCreate)
+
+	req, err := http.NewRequest(http.MethodDelete, "/manage-todo/todo/1", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "Delete", 0)
+
+	req, err = http.NewRequest(http.MethodPost, "/manage-todo/todo/1/shares", bytes.NewBufferString(`{"username": "budi", "role": "editor"}`))
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "ShareTodo", 0)
+}
+
+func TestShareTodo(t *testing.T) {
+	share := func(repo *mocks.TodoRepository, body string) int {
+		handler := NewTodoService(repo)
+		router := gin.New()
+		router.POST("/manage-todo/todo/:id/shares", withUser, handler.TodoShareHandlerCreate)
+
+		req, err := http.NewRequest(http.MethodPost, "/manage-todo/todo/1/shares", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	ownTodo := &entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(ownTodo, nil)
+	repo.On("GetUserByUsername", "budi").Return(&entity.User{Id: 3, Username: "budi"}, nil)
+	repo.On("ShareTodo", &entity.TodoShare{TodoID: 1, UserID: 3, Role: entity.ShareRoleViewer}).Return(nil)
+	assert.Equal(t, http.StatusOK, share(repo, `{"username": "budi", "role": "viewer"}`))
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(ownTodo, nil)
+	repo.On("GetUserByUsername", "saya").Return(&entity.User{Id: 1, Username: "saya"}, nil)
+	assert.Equal(t, http.StatusBadRequest, share(repo, `{"username": "saya", "role": "viewer"}`))
+
+	// role selain viewer/editor ditolak sebelum menyentuh database
+	assert.Equal(t, http.StatusBadRequest, share(mocks.NewTodoRepository(t), `{"username": "budi", "role": "owner"}`))
+}
+
+func serveProjectShares(t *testing.T, repo *mocks.TodoRepository, method, path, body string) int {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/projects/:id/shares", withUser, handler.ProjectShareHandlerCreate)
+	router.DELETE("/projects/:id/shares/:user_id", withUser, handler.ProjectShareHandlerDelete)
+
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestShareProject(t *testing.T) {
+	t.Run("Owner", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetProjectByID", int64(5), int64(1)).Return(&entity.Project{ID: 5, UserID: 1}, nil)
+		repo.On("GetUserByUsername", "budi").Return(&entity.User{Id: 3, Username: "budi"}, nil)
+		repo.On("ShareProject", &entity.ProjectShare{ProjectID: 5, UserID: 3, Role: entity.ShareRoleEditor}).Return(nil)
+
+		code := serveProjectShares(t, repo, http.MethodPost, "/projects/5/shares", `{"username": "budi", "role": "editor"}`)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("WorkspaceProjectOfSomeoneElse", func(t *testing.T) {
+		// project workspace terlihat oleh semua anggota, tapi hanya pembuatnya yang boleh membagikan
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetProjectByID", int64(5), int64(1)).Return(&entity.Project{ID: 5, UserID: 2}, nil)
+
+		code := serveProjectShares(t, repo, http.MethodPost, "/projects/5/shares", `{"username": "budi", "role": "viewer"}`)
+		assert.Equal(t, http.StatusForbidden, code)
+		repo.AssertNumberOfCalls(t, "ShareProject", 0)
+	})
+
+	t.Run("UnknownProject", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetProjectByID", int64(5), int64(1)).Return(nil, nil)
+
+		code := serveProjectShares(t, repo, http.MethodPost, "/projects/5/shares", `{"username": "budi", "role": "viewer"}`)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestRemoveProjectShare(t *testing.T) {
+	// user yang dibagikan boleh melepas share-nya sendiri tanpa melihat project owner
+	repo := mocks.NewTodoRepository(t)
+	repo.On("RemoveProjectShare", int64(5), int64(1)).Return(int64(1), nil)
+	assert.Equal(t, http.StatusOK, serveProjectShares(t, repo, http.MethodDelete, "/projects/5/shares/1", ""))
+	repo.AssertNumberOfCalls(t, "GetProjectByID", 0)
+
+	// share orang lain hanya bisa dicabut owner project
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetProjectByID", int64(5), int64(1)).Return(nil, nil)
+	assert.Equal(t, http.StatusNotFound, serveProjectShares(t, repo, http.MethodDelete, "/projects/5/shares/3", ""))
+	repo.AssertNumberOfCalls(t, "RemoveProjectShare", 0)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/cfg"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func openShareLink(t *testing.T, repo *mocks.TodoRepository, token, password string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.GET("/public/todos/:token", handler.PublicTodoHandler)
+
+	req, err := http.NewRequest(http.MethodGet, "/public/todos/"+token, nil)
+	require.NoError(t, err)
+	if password != "" {
+		req.Header.Set("X-Share-Password", password)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPublicTodoExpiry(t *testing.T) {
+	// token kedaluwarsa ditolak dari signature-nya saja, tanpa query ke database
+	repo := mocks.NewTodoRepository(t)
+	w := openShareLink(t, repo, cfg.SignShareLink(5, time.Now().Add(-time.Minute)), "")
+	assert.Equal(t, http.StatusGone, w.Code)
+
+	// expiry di database yang lebih awal dari token juga berlaku
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetShareLink", int64(5)).Return(&entity.ShareLink{ID: 5, TodoID: 1, ExpiresAt: time.Now().Add(-time.Second)}, nil)
+	w = openShareLink(t, repo, cfg.SignShareLink(5, time.Now().Add(time.Hour)), "")
+	assert.Equal(t, http.StatusGone, w.Code)
+	repo.AssertNumberOfCalls(t, "GetPublicTodo", 0)
+
+	revokedAt := time.Now()
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetShareLink", int64(5)).Return(&entity.ShareLink{ID: 5, TodoID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	w = openShareLink(t, repo, cfg.SignShareLink(5, time.Now().Add(time.Hour)), "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPublicTodoPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("rahasia"), bcrypt.MinCost)
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour)
+	token := cfg.SignShareLink(5, expiresAt)
+	link := &entity.ShareLink{ID: 5, TodoID: 1, UserID: 1, PasswordHash: string(hash), ExpiresAt: expiresAt}
+
+	for _, password := range []string{"", "salah"} {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetShareLink", int64(5)).Return(link, nil)
+
+		w := openShareLink(t, repo, token, password)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "password %q", password)
+		repo.AssertNumberOfCalls(t, "GetPublicTodo", 0)
+	}
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetShareLink", int64(5)).Return(link, nil)
+	repo.On("GetPublicTodo", int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, Title: "Agenda rapat", Status: entity.StatusTodo}, nil)
+
+	w := openShareLink(t, repo, token, "rahasia")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Agenda rapat")
+	assert.NotContains(t, w.Body.String(), "user_id", "public view hides the owner")
+}
+
+func TestPublicTodoPasswordNotFromQuery(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("rahasia"), bcrypt.MinCost)
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour)
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetShareLink", int64(5)).Return(&entity.ShareLink{ID: 5, TodoID: 1, UserID: 1, PasswordHash: string(hash), ExpiresAt: expiresAt}, nil)
+
+	// password di URL ikut tercatat di log akses, jadi tidak diterima lagi
+	w := openShareLink(t, repo, cfg.SignShareLink(5, expiresAt)+"?password=rahasia", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	repo.AssertNumberOfCalls(t, "GetPublicTodo", 0)
+}
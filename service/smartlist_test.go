@@ -0,0 +1,89 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+)
+
+func serveSmartList(t *testing.T, repo *mocks.TodoRepository, target string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.GET("/manage-todos/smart/:list", withUser, handler.TodolistSmartListHandler)
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSmartListToday(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+
+	repo := mocks.NewTodoRepository(t)
+	var from *time.Time
+	var to time.Time
+	repo.On("GetDueBetween", int64(1), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			from = args.Get(1).(*time.Time)
+			to = args.Get(2).(time.Time)
+		}).
+		Return([]entity.Todolist{{ID: 1, Title: "Bayar listrik"}}, nil)
+
+	w := serveSmartList(t, repo, "/manage-todos/smart/today?tz=Asia/Jakarta")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp request.SmartListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "today", resp.List)
+	assert.Equal(t, "Asia/Jakarta", resp.TimeZone)
+	assert.Equal(t, 1, resp.Data)
+
+	// batas hari mengikuti tengah malam di zona waktu pemanggil, bukan UTC
+	require.NotNil(t, from)
+	local := from.In(jakarta)
+	assert.Equal(t, 0, local.Hour())
+	assert.Equal(t, 0, local.Minute())
+	assert.Equal(t, 24*time.Hour, to.Sub(*from))
+}
+
+func TestSmartListOverdueHasNoLowerBound(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetDueBetween", int64(1), (*time.Time)(nil), mock.AnythingOfType("time.Time")).
+		Return([]entity.Todolist{}, nil)
+
+	w := serveSmartList(t, repo, "/manage-todos/smart/overdue")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSmartListNoDate(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetWithoutDueDate", int64(1)).Return([]entity.Todolist{{ID: 2}}, nil)
+
+	w := serveSmartList(t, repo, "/manage-todos/smart/no-date")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSmartListRejectsBadInput(t *testing.T) {
+	t.Run("UnknownList", func(t *testing.T) {
+		w := serveSmartList(t, mocks.NewTodoRepository(t), "/manage-todos/smart/someday")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		req := "/manage-todos/smart/today?tz=Mars/Olympus"
+		w := serveSmartList(t, mocks.NewTodoRepository(t), req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -34,16 +34,16 @@ func TestGetAll1(t *testing.T) {
 			name:               "Success",
 			expectedStatusCode: http.StatusOK,
 			mockTodo: []entity.Todolist{
-				{ID: 1, Title: "Task 1", Status: false},
-				{ID: 2, Title: "Task 2", Status: false},
+				{ID: 1, Title: "Task 1", Status: entity.StatusTodo},
+				{ID: 2, Title: "Task 2", Status: entity.StatusTodo},
 			},
 			mockErr: nil,
 			expectedResponse: request.TodoResponseToGetAll{
 				Message: "Success Get All",
 				Data:    2,
 				Todos: []entity.Todolist{
-					{ID: 1, Title: "Task 1", Status: false},
-					{ID: 2, Title: "Task 2", Status: false},
+					{ID: 1, Title: "Task 1", Status: entity.StatusTodo},
+					{ID: 2, Title: "Task 2", Status: entity.StatusTodo},
 				},
 			},
 		},
@@ -74,7 +74,7 @@ func TestGetAll1(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			repo := mocks.NewTodoRepository(t)
-			repo.On("GetAll").Return(tc.mockTodo, tc.mockErr)
+			repo.On("GetAllUserByID", int64(1), mock.Anything).Return(tc.mockTodo, tc.mockErr)
 
 			handler := NewTodoService(repo)
 
@@ -85,7 +85,7 @@ func TestGetAll1(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			router := gin.Default()
-			router.GET("/manage-todos", handler.TodolistHandlerGetAll)
+			router.GET("/manage-todos", withUser, handler.TodolistHandlerGetAll)
 			router.ServeHTTP(w, r)
 
 			assert.Equal(t, tc.expectedStatusCode, w.Code)
@@ -121,24 +121,27 @@ func TestCreate1(t *testing.T) {
 		{
 			name: "Success",
 			body: `{"title": "Makan"}`,
-			mock: func(mock *mocks.TodoRepository) {
+			mock: func(repo *mocks.TodoRepository) {
 				newTodo := &entity.Todolist{
 					Title:  "Makan",
-					Status: false,
+					Status: entity.StatusTodo,
 				}
-				mock.On("Create", "Makan").Return(newTodo, nil)
+				repo.On("Create", mock.MatchedBy(func(todo *entity.Todolist) bool {
+					return todo.Title == "Makan" && todo.UserID == 1
+				})).Return(newTodo, nil)
+				repo.On("AddHistory", mock.Anything).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedData: entity.Todolist{
 				Title:  "Makan",
-				Status: false,
+				Status: entity.StatusTodo,
 			},
 			expectedError: "",
 		},
 		{
 			name:           "Invalid input",
 			body:           `{"title": ""}`,
-			mock:           func(mock *mocks.TodoRepository) {},
+			mock:           func(repo *mocks.TodoRepository) {},
 			expectedStatus: http.StatusBadRequest,
 			expectedData:   entity.Todolist{},
 			expectedError:  "Invalid input",
@@ -146,9 +149,9 @@ func TestCreate1(t *testing.T) {
 		{
 			name: "Internal Server Error",
 			body: `{"title": "Test Todo"}`,
-			mock: func(mock *mocks.TodoRepository) {
+			mock: func(repo *mocks.TodoRepository) {
 				expectedError := errors.New("Internal Server Error")
-				mock.On("Create", "Test Todo").Return(nil, expectedError)
+				repo.On("Create", mock.Anything).Return(nil, expectedError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedData:   entity.Todolist{},
@@ -170,7 +173,7 @@ func TestCreate1(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(w)
-			r.POST(endpoint, handler.TodolistHandlerCreate)
+			r.POST(endpoint, withUser, handler.TodolistHandlerCreate)
 
 			c.Request = req
 			r.ServeHTTP(w, req)
@@ -244,14 +247,17 @@ func TestTodolistHandlerDelete(t *testing.T) {
 		},
 	}
 
+	mockRepo.On("AddHistory", mock.Anything).Return(nil).Maybe()
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			mockRepo.On("Delete", tc.todoID).Return(tc.isFound, tc.repoError)
+			mockRepo.On("GetByID", tc.todoID, int64(1)).Return(&entity.Todolist{ID: tc.todoID, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+			mockRepo.On("Delete", tc.todoID, int64(1)).Return(tc.isFound, tc.repoError)
 
 			w := httptest.NewRecorder()
 			r, _ := http.NewRequest(http.MethodDelete, "/manage-todo/todo/"+strconv.FormatInt(tc.todoID, 10), nil)
 			router := gin.Default()
-			router.DELETE("/manage-todo/todo/:id", handler.TodolistHandlerDelete)
+			router.DELETE("/manage-todo/todo/:id", withUser, handler.TodolistHandlerDelete)
 			router.ServeHTTP(w, r)
 
 			assert.Equal(t, tc.expStatus, w.Code)
@@ -313,12 +319,12 @@ func TestGetByID1(t *testing.T) {
 			mockTodoRepo := mocks.NewTodoRepository(t)
 			handler := NewTodoService(mockTodoRepo)
 
-			mockTodoRepo.On("GetByID", tc.inputID).Return(tc.mockResult, tc.mockError)
+			mockTodoRepo.On("GetByID", tc.inputID, int64(1)).Return(tc.mockResult, tc.mockError)
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest("GET", fmt.Sprintf("/manage-todo/todo/%d", tc.inputID), nil)
 			router := gin.Default()
-			router.GET("/manage-todo/todo/:id", handler.TodolistHandlerGetByID)
+			router.GET("/manage-todo/todo/:id", withUser, handler.TodolistHandlerGetByID)
 			router.ServeHTTP(w, req)
 
 			respBody, err := io.ReadAll(w.Body)
@@ -363,10 +369,11 @@ func TestUpdate1(t *testing.T) {
 				expectedTodo := entity.Todolist{
 					ID:     1,
 					Title:  "New Title",
-					Status: false,
+					Status: entity.StatusTodo,
 				}
-				mockRepo.On("GetByID", int64(1)).Return(&entity.Todolist{}, nil)
-				mockRepo.On("Update", int64(1), mock.Anything).Return(&expectedTodo, nil)
+				mockRepo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{UserID: 1, AccessRole: entity.AccessOwner}, nil)
+				mockRepo.On("Update", int64(1), int64(1), mock.Anything).Return(&expectedTodo, nil)
+				mockRepo.On("AddHistory", mock.Anything).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedResp: map[string]interface{}{
@@ -383,7 +390,7 @@ func TestUpdate1(t *testing.T) {
 				Title: "New Title",
 			},
 			mockBehavior: func() {
-				mockRepo.On("GetByID", int64(2)).Return(nil, nil)
+				mockRepo.On("GetByID", int64(2), int64(1)).Return(nil, nil)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedResp: respErr.ErrorResponse{
@@ -396,12 +403,11 @@ func TestUpdate1(t *testing.T) {
 			name: "Internal Server Error",
 			id:   3,
 			requestPayload: request.TodolistUpdateRequest{
-				Title:  "New Title",
-				Status: false,
+				Title: "New Title",
 			},
 			mockBehavior: func() {
-				mockRepo.On("GetByID", int64(3)).Return(&entity.Todolist{}, nil)
-				mockRepo.On("Update", int64(3), mock.Anything).Return(nil, errors.New("Internal Server Error"))
+				mockRepo.On("GetByID", int64(3), int64(1)).Return(&entity.Todolist{UserID: 1, AccessRole: entity.AccessOwner}, nil)
+				mockRepo.On("Update", int64(3), int64(1), mock.Anything).Return(nil, errors.New("Internal Server Error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedResp: respErr.ErrorResponse{
@@ -422,7 +428,7 @@ func TestUpdate1(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			r := gin.Default()
-			r.PUT("/manage-todo/todo/:id", handler.TodolistHandlerUpdate)
+			r.PUT("/manage-todo/todo/:id", withUser, handler.TodolistHandlerUpdate)
 			r.ServeHTTP(w, req)
 
 			respBody, err := io.ReadAll(w.Body)
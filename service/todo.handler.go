@@ -10,7 +10,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strconv"
-	"todoGin/cfg"
+	"time"
 	"todoGin/model/entity"
 	"todoGin/model/request"
 	"todoGin/model/respErr"
@@ -19,11 +19,19 @@ import (
 
 type Handler struct {
 	TodoRepository repository.TodoRepository
+	Workflow       Workflow
+	Denylist       *TokenDenylist
+	Sessions       *SessionTracker
+	Accounts       *AccountCache
 }
 
 func NewTodoService(todoRepo repository.TodoRepository) *Handler {
 	return &Handler{
 		TodoRepository: todoRepo,
+		Workflow:       DefaultWorkflow(),
+		Denylist:       NewTokenDenylist(todoRepo, 30*time.Second),
+		Sessions:       NewSessionTracker(todoRepo, time.Minute),
+		Accounts:       NewAccountCache(todoRepo, 30*time.Second),
 	}
 }
 
@@ -39,7 +47,7 @@ func (h *Handler) Register(ctx *gin.Context) {
 	}
 
 	// cek apakah pengguna sudah ada di database
-	existingUser, err := h.TodoRepository.GetUserByUsername(user.Username)
+	existingUser, err := h.repo(ctx).GetUserByUsername(user.Username)
 	if existingUser != nil {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.Error{
 			Error: "User already exist",
@@ -61,7 +69,7 @@ func (h *Handler) Register(ctx *gin.Context) {
 		Username: user.Username,
 		Password: string(hashedPassword),
 	}
-	err = h.TodoRepository.CreateUser(newUser)
+	err = h.repo(ctx).CreateUser(newUser)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
 			Error: "Failed Create User",
@@ -85,7 +93,7 @@ func (h *Handler) Login(ctx *gin.Context) {
 	}
 
 	// cek apakah pengguna ada di database
-	storedUser, err := h.TodoRepository.GetUserByUsername(user.Username)
+	storedUser, err := h.repo(ctx).GetUserByUsername(user.Username)
 	if err != nil || storedUser == nil {
 		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
 			Error: "invalid Username or Password",
@@ -102,18 +110,49 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	userID := storedUser.Id
+	// akun yang dinonaktifkan admin atau wajib reset password tidak diberi token
+	if storedUser.DisabledAt != nil {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.Error{
+			Error: "Account is disabled",
+		})
+		return
+	}
+	if storedUser.PasswordResetRequired {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.Error{
+			Error: "Password reset required, use /password-reset",
+		})
+		return
+	}
+
+	// user dengan 2FA aktif harus memasukkan kode dulu lewat /login/2fa
+	if storedUser.TOTPEnabledAt != nil {
+		h.requireSecondFactor(ctx, storedUser)
+		return
+	}
 
-	// membuat token
-	token, err := cfg.CreateToken(user.Username, userID)
+	h.completeLogin(ctx, storedUser)
+}
+
+// completeLogin membuat sesi baru, lalu access token dan refresh token untuk sesi tersebut.
+func (h *Handler) completeLogin(ctx *gin.Context, storedUser *entity.User) {
+	session, err := h.createSession(ctx, storedUser.Id)
+	if err != nil {
+		logrus.Errorf("failed when creating session: %v", err)
+		ctx.JSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to generate Token",
+		})
+		return
+	}
+	tokens, _, err := h.issueTokens(storedUser, session.ID, 0)
 	if err != nil {
+		logrus.Errorf("failed when generating tokens: %v", err)
 		ctx.JSON(http.StatusInternalServerError, respErr.Error{
 			Error: "Failed to generate Token",
 		})
 		return
 	}
 
-	_, err = h.TodoRepository.GetAllUserByID(storedUser.Id)
+	_, err = h.repo(ctx).GetAllUserByID(storedUser.Id, request.TodoFilter{})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, respErr.Error{
 			Error: "Failed to get Todolist",
@@ -123,9 +162,11 @@ func (h *Handler) Login(ctx *gin.Context) {
 
 	// Membuat response
 	response := request.LoginResponse{
-		Message: fmt.Sprintf("Hello %s! You are now logged in.", user.Username),
-		Token:   token,
-		UserID:  int(storedUser.Id),
+		Message:      fmt.Sprintf("Hello %s! You are now logged in.", storedUser.Username),
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		UserID:       int(storedUser.Id),
 		//Todolist: todolist,
 	}
 
@@ -176,7 +217,12 @@ func (h *Handler) TodolistHandlerGetAll(ctx *gin.Context) {
 		return
 	}
 
-	todos, err := h.TodoRepository.GetAllUserByID(userIDInt64)
+	filter, ok := parseTodoFilter(ctx)
+	if !ok {
+		return
+	}
+
+	todos, err := h.repo(ctx).GetAllUserByID(userIDInt64, filter)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, &respErr.ErrorResponse{
 			Message: err.Error(),
@@ -233,9 +279,48 @@ func (h *Handler) TodolistHandlerCreate(ctx *gin.Context) {
 	// Set the user ID in the TodolistCreateRequest
 	todolist.UserID = userIDInt64
 
-	// Rest of your existing code...
+	if todolist.StartAt != nil && todolist.DueAt != nil && todolist.StartAt.After(*todolist.DueAt) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "start_at must be before due_at",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if todolist.ProjectID != nil {
+		if _, ok := h.loadProject(ctx, *todolist.ProjectID, userIDInt64); !ok {
+			return
+		}
+	}
+
+	recurrence, err := normalizeRecurrence(todolist.Recurrence)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: fmt.Sprintf("Invalid recurrence: %v", err),
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+	if recurrence != "" && todolist.DueAt == nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "recurrence requires due_at",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
 
-	newTodo, errCreate := h.TodoRepository.Create(todolist.Title, todolist.UserID)
+	newTodo, errCreate := h.repo(ctx).Create(&entity.Todolist{
+		Title:           todolist.Title,
+		Description:     todolist.Description,
+		Status:          todolist.Status,
+		StartAt:         todolist.StartAt,
+		DueAt:           todolist.DueAt,
+		Recurrence:      recurrence,
+		Priority:        todolist.Priority,
+		EstimateMinutes: todolist.EstimateMinutes,
+		UserID:          todolist.UserID,
+		ProjectID:       todolist.ProjectID,
+	})
 	if errCreate != nil {
 		logrus.Error(errCreate)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
@@ -247,6 +332,7 @@ func (h *Handler) TodolistHandlerCreate(ctx *gin.Context) {
 
 	// Log the newly created Todo for debugging purposes
 	logrus.Info("Newly Created Todo:", newTodo)
+	h.recordHistory(newTodo.ID, userIDInt64, entity.HistoryCreated, nil, newTodo)
 
 	logrus.Info(http.StatusOK, " Success Create Todo", todolist)
 	ctx.JSON(http.StatusOK, request.TodoResponse{
@@ -289,7 +375,7 @@ func (h *Handler) TodolistHandlerGetByID(ctx *gin.Context) {
 		})
 		return
 	}
-	todo, err := h.TodoRepository.GetByID(todoID, userIDInt64)
+	todo, err := h.repo(ctx).GetByID(todoID, userIDInt64)
 	if err != nil {
 		logrus.Errorf("failed when get todo by id: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
@@ -355,7 +441,7 @@ func (h *Handler) TodolistHandlerUpdate(ctx *gin.Context) {
 		})
 		return
 	}
-	ErrId, err := h.TodoRepository.GetByID(todoID, userIDInt64)
+	ErrId, err := h.repo(ctx).GetByID(todoID, userIDInt64)
 	if err != nil {
 		logrus.Errorf("failed when get todo by id: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
@@ -371,16 +457,73 @@ func (h *Handler) TodolistHandlerUpdate(ctx *gin.Context) {
 		})
 		return
 	}
-	rowsAffected, err := h.TodoRepository.Update(todoID, userIDInt64, reqBody.ReqTodo())
-	if err != nil {
-		logrus.Errorf("failed when updating todo: %v", err)
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
-			Message: "Internal Server Error",
-			Status:  http.StatusInternalServerError,
+	if !canEditTodo(ErrId.AccessRole) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "You only have view access to this todo",
+			Status:  http.StatusForbidden,
+		})
+		return
+	}
+
+	// pastikan start_at tidak melewati due_at setelah update
+	startAt, dueAt := ErrId.StartAt, ErrId.DueAt
+	if reqBody.StartAt != nil {
+		startAt = reqBody.StartAt
+	} else if reqBody.ClearStartAt {
+		startAt = nil
+	}
+	if reqBody.DueAt != nil {
+		dueAt = reqBody.DueAt
+	} else if reqBody.ClearDueAt {
+		dueAt = nil
+	}
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "start_at must be before due_at",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	recurrence := ErrId.Recurrence
+	if reqBody.Recurrence != nil {
+		recurrence, err = normalizeRecurrence(*reqBody.Recurrence)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: fmt.Sprintf("Invalid recurrence: %v", err),
+				Status:  http.StatusBadRequest,
+			})
+			return
+		}
+		reqBody.Recurrence = &recurrence
+	}
+	if recurrence != "" && dueAt == nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "recurrence requires due_at",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// status hanya boleh pindah sesuai workflow
+	statusChanged := reqBody.Status != nil && *reqBody.Status != ErrId.Status
+	if statusChanged && !h.Workflow.CanTransition(ErrId.Status, *reqBody.Status) {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: fmt.Sprintf("Illegal status transition from %s to %s", ErrId.Status, *reqBody.Status),
+			Status:  http.StatusConflict,
 		})
 		return
 	}
-	if rowsAffected == nil {
+	if statusChanged && *reqBody.Status == entity.StatusDone && ErrId.Blocked {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: fmt.Sprintf("Todo is blocked by open todos %v", ErrId.BlockedBy),
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+
+	updates := reqBody.ReqTodo()
+	if len(updates) == 0 && !statusChanged {
 		ctx.AbortWithStatusJSON(http.StatusOK, request.TodoIDResponse{
 			Message: "Not Change",
 			Data:    reqBody,
@@ -388,11 +531,100 @@ func (h *Handler) TodolistHandlerUpdate(ctx *gin.Context) {
 		return
 	}
 
+	if !statusChanged {
+		// editor mengubah todo milik owner, jadi update memakai user_id owner
+		rowsAffected, err := h.repo(ctx).Update(todoID, ErrId.UserID, updates)
+		if err != nil {
+			logrus.Errorf("failed when updating todo: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if rowsAffected == nil {
+			ctx.AbortWithStatusJSON(http.StatusOK, request.TodoIDResponse{
+				Message: "Not Change",
+				Data:    reqBody,
+			})
+			return
+		}
+		h.recordHistory(todoID, userIDInt64, entity.HistoryUpdated, todoFieldValues(ErrId, updates), updates)
+	} else {
+		// todo berulang yang selesai langsung dibuatkan instance berikutnya dari nilai setelah update
+		var next *entity.Todolist
+		if *reqBody.Status == entity.StatusDone && recurrence != "" && dueAt != nil {
+			updated := *ErrId
+			if reqBody.Title != "" {
+				updated.Title = reqBody.Title
+			}
+			if reqBody.Description != nil {
+				updated.Description = *reqBody.Description
+			}
+			if reqBody.Priority != nil {
+				updated.Priority = *reqBody.Priority
+			}
+			if reqBody.EstimateMinutes != nil {
+				updated.EstimateMinutes = reqBody.EstimateMinutes
+			} else if reqBody.ClearEstimate {
+				updated.EstimateMinutes = nil
+			}
+			updated.StartAt, updated.DueAt, updated.Recurrence = startAt, dueAt, recurrence
+
+			next, err = nextOccurrenceTodo(&updated)
+			if err != nil {
+				logrus.Errorf("failed when computing next occurrence: %v", err)
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+					Message: "Internal Server Error",
+					Status:  http.StatusInternalServerError,
+				})
+				return
+			}
+		}
+
+		// perubahan field lain ikut disimpan di transaksi yang sama, jadi kalau status
+		// bentrok (409) tidak ada perubahan yang tersimpan
+		_, err = h.repo(ctx).ChangeStatus(todoID, ErrId.Status, *reqBody.Status, updates, next)
+		if errors.Is(err, repository.ErrConflict) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+				Message: "Todo status was changed by another request",
+				Status:  http.StatusConflict,
+			})
+			return
+		}
+		if err != nil {
+			logrus.Errorf("failed when changing todo status: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if len(updates) > 0 {
+			h.recordHistory(todoID, userIDInt64, entity.HistoryUpdated, todoFieldValues(ErrId, updates), updates)
+		}
+		h.recordHistory(todoID, userIDInt64, entity.HistoryStatusChanged,
+			gin.H{"status": ErrId.Status}, gin.H{"status": *reqBody.Status})
+		if next != nil {
+			logrus.Info("Next occurrence created:", next.ID)
+			h.recordHistory(next.ID, ErrId.UserID, entity.HistoryCreated, nil, next)
+		}
+	}
+
+	// response berisi todo yang sudah tersimpan, termasuk description_html
+	var todos interface{} = reqBody
+	updated, err := h.repo(ctx).GetByID(todoID, userIDInt64)
+	if err != nil {
+		logrus.Errorf("failed when get updated todo: %v", err)
+	} else if updated != nil {
+		todos = updated
+	}
+
 	logrus.Info(http.StatusOK, " Success Update Todo")
 	ctx.JSON(http.StatusOK, request.TodoUpdateResponse{
 		Status:  http.StatusOK,
 		Message: "Success Update Todo",
-		Todos:   reqBody,
+		Todos:   todos,
 	})
 
 }
@@ -430,8 +662,34 @@ func (h *Handler) TodolistHandlerDelete(ctx *gin.Context) {
 		return
 	}
 
-	// Delete the Todolist with the specified todoID and userID
-	isDeleted, err := h.TodoRepository.Delete(todoID, userIDInt64)
+	// simpan kondisi terakhir untuk history sebelum dihapus
+	todo, err := h.repo(ctx).GetByID(todoID, userIDInt64)
+	if err != nil {
+		logrus.Errorf("failed when get todo by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if todo == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Not Found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	// todo yang dibagikan hanya boleh dihapus pemiliknya atau admin workspace
+	if !canDeleteTodo(todo.AccessRole) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "Only the owner can delete this todo",
+			Status:  http.StatusForbidden,
+		})
+		return
+	}
+
+	// Delete the Todolist with the specified todoID and its owner
+	isDeleted, err := h.repo(ctx).Delete(todoID, todo.UserID)
 	if err != nil {
 		logrus.Errorf("failed when deleting todo: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
@@ -449,6 +707,8 @@ func (h *Handler) TodolistHandlerDelete(ctx *gin.Context) {
 		return
 	}
 
+	h.recordHistory(todoID, userIDInt64, entity.HistoryDeleted, todo, nil)
+
 	logrus.Info(http.StatusOK, " Success DELETE")
 	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
 		Status:  http.StatusOK,
@@ -491,12 +751,8 @@ func (h *Handler) UploadTodoFileS3AtchHandler(ctx *gin.Context) {
 	}
 
 	// Check if the Todo with the given ID exists
-	todo, err := h.TodoRepository.GetByID(todoID, userIDInt64)
-	if err != nil {
-		ctx.JSON(http.StatusNotFound, respErr.ErrorResponse{
-			Message: "Todo not found",
-			Status:  http.StatusNotFound,
-		})
+	todo, ok := h.loadEditableTodo(ctx, todoID, userIDInt64)
+	if !ok {
 		return
 	}
 
@@ -526,7 +782,7 @@ func (h *Handler) UploadTodoFileS3AtchHandler(ctx *gin.Context) {
 	}
 
 	// Use the TodoRepository to upload the file to S3
-	attachment, err := h.TodoRepository.UploadTodoFileS3Atch(file, todoID, userIDInt64)
+	attachment, err := h.repo(ctx).UploadTodoFileS3Atch(file, todoID, todo.UserID)
 	if err != nil {
 		// Periksa apakah error merupakan "Todolist not found" atau bukan
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -550,7 +806,7 @@ func (h *Handler) UploadTodoFileS3AtchHandler(ctx *gin.Context) {
 	todo.Attachments = append(todo.Attachments, *attachment)
 
 	// Create an attachment record in the database
-	err = h.TodoRepository.UpdateTodoWithAttachments(todo)
+	err = h.repo(ctx).UpdateTodoWithAttachments(todo)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, respErr.ErrorResponse{
 			Message: "Failed to update Todo with attachments",
@@ -558,6 +814,7 @@ func (h *Handler) UploadTodoFileS3AtchHandler(ctx *gin.Context) {
 		})
 		return
 	}
+	h.recordHistory(todoID, userIDInt64, entity.HistoryAttachmentUploaded, nil, attachment)
 
 	ctx.JSON(http.StatusOK, request.SuccessMessage{
 		Message: "File uploaded and attachment created successfully",
@@ -585,7 +842,7 @@ func (h *Handler) UploadFileS3BucketsHandler(ctx *gin.Context) {
 	defer src.Close()
 
 	// Use the TodoRepository to upload the file to S3
-	publicURL, err := h.TodoRepository.UploadFileS3Buckets(src, file.Filename)
+	publicURL, err := h.repo(ctx).UploadFileS3Buckets(src, file.Filename)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to upload file to S3",
@@ -635,12 +892,8 @@ func (h *Handler) UploadTodoLocalAtchHandler(ctx *gin.Context) {
 	}
 
 	// Check if the Todo with given ID exists
-	todo, err := h.TodoRepository.GetByID(todoID, userIDInt64)
-	if err != nil {
-		ctx.JSON(http.StatusNotFound, respErr.ErrorResponse{
-			Message: "Todo not found",
-			Status:  http.StatusBadRequest,
-		})
+	todo, ok := h.loadEditableTodo(ctx, todoID, userIDInt64)
+	if !ok {
 		return
 	}
 
@@ -670,7 +923,7 @@ func (h *Handler) UploadTodoLocalAtchHandler(ctx *gin.Context) {
 	}
 
 	// use
-	attachment, err := h.TodoRepository.UploadTodoFileLocalAtch(file, todoID, userIDInt64)
+	attachment, err := h.repo(ctx).UploadTodoFileLocalAtch(file, todoID, todo.UserID)
 	if err != nil {
 		// Periksa apakah error merupakan "Todolist not found" atau bukan
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -690,15 +943,13 @@ func (h *Handler) UploadTodoLocalAtchHandler(ctx *gin.Context) {
 		return
 	}
 
-	// Update the Todo's Attachments field with the new attachment
-	todo.Attachments = append(todo.Attachments, *attachment)
-
-	// Save the updated Todo to the database
-	err = h.TodoRepository.UpdatetoAtch(todo)
+	// Save the new attachment, the todo row itself is left untouched
+	err = h.repo(ctx).UpdatetoAtch(attachment)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update Todo"})
 		return
 	}
+	h.recordHistory(todoID, userIDInt64, entity.HistoryAttachmentUploaded, nil, attachment)
 
 	ctx.JSON(http.StatusOK, request.SuccessMessage{
 		Status:  http.StatusOK,
@@ -736,7 +987,12 @@ func (h *Handler) TodolistsSearchHandler(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	perPage, _ := strconv.Atoi(ctx.DefaultQuery("per_page", "10"))
 
-	todolists, total, err := h.TodoRepository.SearchTodolistByUser(userIDInt64, search, page, perPage)
+	filter, ok := parseTodoFilter(ctx)
+	if !ok {
+		return
+	}
+
+	todolists, total, err := h.repo(ctx).SearchTodolistByUser(userIDInt64, search, page, perPage, filter)
 	if err != nil {
 		logrus.Errorf("failed when searching todos: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
@@ -756,4 +1012,240 @@ func (h *Handler) TodolistsSearchHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// TodolistSmartListHandler menampilkan smart list (today, overdue, upcoming, no-date)
+// berdasarkan zona waktu pemanggil dari query "tz" atau header "Time-Zone".
+func (h *Handler) TodolistSmartListHandler(ctx *gin.Context) {
+	userID, _ := ctx.Get("user_id")
+	if userID == nil {
+		logrus.Error("User not authenticated")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.ErrorResponse{
+			Message: "User not authenticated",
+			Status:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	// Cast userID ke int64
+	userIDInt64, ok := userID.(int64)
+	if !ok {
+		logrus.Error("Invalid user_id")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid user_id",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	tz := ctx.Query("tz")
+	if tz == "" {
+		tz = ctx.GetHeader("Time-Zone")
+	}
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid time zone",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// batas hari dihitung di zona waktu pemanggil
+	now := time.Now().In(loc)
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	startOfTomorrow := startOfToday.AddDate(0, 0, 1)
+
+	var todos []entity.Todolist
+	list := ctx.Param("list")
+	switch list {
+	case "today":
+		todos, err = h.repo(ctx).GetDueBetween(userIDInt64, &startOfToday, startOfTomorrow)
+	case "overdue":
+		todos, err = h.repo(ctx).GetDueBetween(userIDInt64, nil, now)
+	case "upcoming":
+		todos, err = h.repo(ctx).GetDueBetween(userIDInt64, &startOfTomorrow, startOfTomorrow.AddDate(0, 0, 7))
+	case "no-date":
+		todos, err = h.repo(ctx).GetWithoutDueDate(userIDInt64)
+	default:
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Unknown smart list",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when get smart list %s: %v", list, err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SmartListResponse{
+		Message:  "Success Get Smart List",
+		List:     list,
+		TimeZone: loc.String(),
+		Data:     len(todos),
+		Todos:    todos,
+	})
+}
+
+// nextOccurrenceTodo menyiapkan instance berikutnya dari todo berulang yang akan ditutup,
+// nil kalau recurrence sudah habis. start_at ikut digeser dengan jarak yang sama terhadap due_at.
+func nextOccurrenceTodo(done *entity.Todolist) (*entity.Todolist, error) {
+	nextDue, nextRecurrence, ok, err := nextOccurrence(done.Recurrence, *done.DueAt)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	next := &entity.Todolist{
+		Title:           done.Title,
+		Description:     done.Description,
+		DueAt:           &nextDue,
+		Recurrence:      nextRecurrence,
+		Priority:        done.Priority,
+		EstimateMinutes: done.EstimateMinutes,
+		UserID:          done.UserID,
+		ProjectID:       done.ProjectID,
+		WorkspaceID:     done.WorkspaceID,
+		AssigneeID:      done.AssigneeID,
+	}
+	if done.StartAt != nil {
+		nextStart := nextDue.Add(done.StartAt.Sub(*done.DueAt))
+		next.StartAt = &nextStart
+	}
+	return next, nil
+}
+
+// TodolistOccurrencesHandler menampilkan N kejadian berikutnya dari todo berulang.
+func (h *Handler) TodolistOccurrencesHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	count, err := strconv.Atoi(ctx.DefaultQuery("count", "5"))
+	if err != nil || count < 1 || count > 100 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "count must be between 1 and 100",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	todo, ok := h.loadTodo(ctx, todoID, userID)
+	if !ok {
+		return
+	}
+	if todo.Recurrence == "" || todo.DueAt == nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Todo is not recurring",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	occurrences, err := previewOccurrences(todo.Recurrence, *todo.DueAt, count)
+	if err != nil {
+		logrus.Errorf("failed when preview occurrences: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Occurrences",
+		Data: gin.H{
+			"recurrence":  todo.Recurrence,
+			"occurrences": occurrences,
+		},
+	})
+}
+
+// TodolistReorderHandler memindahkan todo sebelum atau sesudah todo lain (drag-and-drop).
+func (h *Handler) TodolistReorderHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TodoReorderRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil || (reqBody.BeforeID == nil) == (reqBody.AfterID == nil) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Exactly one of before_id or after_id is required",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	targetID, after := reqBody.AfterID, true
+	if reqBody.BeforeID != nil {
+		targetID, after = reqBody.BeforeID, false
+	}
+	if *targetID == todoID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Cannot move a todo relative to itself",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// user yang boleh mengubah kedua todo boleh mengurutkannya, termasuk editor dan assignee
+	todo, ok := h.loadEditableTodo(ctx, todoID, userID)
+	if !ok {
+		return
+	}
+	target, ok := h.loadEditableTodo(ctx, *targetID, userID)
+	if !ok {
+		return
+	}
+	if !sameTodoList(todo, target) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Todos are not in the same list",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	// urutan disimpan per list milik owner todo
+	position, err := h.repo(ctx).MoveTodo(todoID, todo.UserID, *targetID, after)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Target todo not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when reordering todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryReordered,
+		gin.H{"position": todo.Position}, gin.H{"position": position})
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Todo reordered",
+		Data:    gin.H{"todo_id": todoID, "position": position},
+	})
+}
+
 ///////////////////////////////////////////////////////////////////
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+	"todoGin/cfg"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// issueTokens membuat access token dan refresh token baru untuk sesi yang diberikan,
+// id sesi sekaligus menjadi family refresh token.
+// rotateID diisi id refresh token lama saat rotasi, 0 untuk login.
+func (h *Handler) issueTokens(user *entity.User, sessionID string, rotateID int64) (*request.TokenResponse, bool, error) {
+	accessToken, err := cfg.CreateToken(user.Username, user.Id, sessionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	refreshToken, hash, err := cfg.NewRefreshToken()
+	if err != nil {
+		return nil, false, err
+	}
+	next := &entity.RefreshToken{
+		UserID:    user.Id,
+		FamilyID:  sessionID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(cfg.RefreshTokenTTL()),
+	}
+
+	if rotateID == 0 {
+		err = h.TodoRepository.CreateRefreshToken(next)
+	} else {
+		var rotated bool
+		rotated, err = h.TodoRepository.RotateRefreshToken(rotateID, next)
+		if err == nil && !rotated {
+			return nil, false, nil
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &request.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(cfg.AccessTokenTTL().Seconds()),
+	}, true, nil
+}
+
+// createSession mencatat sesi baru saat login.
+func (h *Handler) createSession(ctx *gin.Context, userID int64) (*entity.Session, error) {
+	userAgent := ctx.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         ctx.ClientIP(),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := h.TodoRepository.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// RefreshTokenHandler menukar refresh token dengan access token dan refresh token baru.
+// Refresh token yang sudah pernah dipakai dianggap bocor, seluruh family-nya dicabut.
+func (h *Handler) RefreshTokenHandler(ctx *gin.Context) {
+	reqBody := new(request.RefreshTokenRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.Error{
+			Error: "invalid request Body",
+		})
+		return
+	}
+
+	stored, err := h.TodoRepository.GetRefreshToken(cfg.HashRefreshToken(reqBody.RefreshToken))
+	if err != nil {
+		logrus.Errorf("failed when get refresh token: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to refresh Token",
+		})
+		return
+	}
+	if stored == nil || stored.ExpiresAt.Before(time.Now()) {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid or expired refresh token",
+		})
+		return
+	}
+
+	// sesi yang sudah dicabut tidak bisa diperpanjang
+	active, err := h.Sessions.IsActive(stored.FamilyID)
+	if err != nil {
+		logrus.Errorf("failed when checking session: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to refresh Token",
+		})
+		return
+	}
+	if !active {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid or expired refresh token",
+		})
+		return
+	}
+
+	if stored.RevokedAt != nil {
+		h.revokeReusedFamily(ctx, stored)
+		return
+	}
+
+	user, err := h.TodoRepository.GetUserByID(stored.UserID)
+	if err != nil {
+		logrus.Errorf("failed when get user by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to refresh Token",
+		})
+		return
+	}
+	if user == nil || user.DisabledAt != nil || user.PasswordResetRequired {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid or expired refresh token",
+		})
+		return
+	}
+
+	tokens, rotated, err := h.issueTokens(user, stored.FamilyID, stored.ID)
+	if err != nil {
+		logrus.Errorf("failed when rotating refresh token: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to refresh Token",
+		})
+		return
+	}
+	// token lama sudah dirotasi oleh request lain di antara pengecekan dan rotasi
+	if !rotated {
+		h.revokeReusedFamily(ctx, stored)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// revokeReusedFamily mencabut sesi dari refresh token yang dipakai ulang.
+func (h *Handler) revokeReusedFamily(ctx *gin.Context, stored *entity.RefreshToken) {
+	logrus.Warnf("refresh token reuse detected for user %d, revoking session %s", stored.UserID, stored.FamilyID)
+	if _, err := h.TodoRepository.RevokeSession(stored.FamilyID, stored.UserID); err != nil {
+		logrus.Errorf("failed when revoking session: %v", err)
+	}
+	h.Sessions.Forget(stored.FamilyID)
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+		Error: "refresh token has already been used",
+	})
+}
+
+// LogoutHandler mencabut access token yang sedang dipakai beserta sesinya,
+// sehingga refresh token dari sesi ini juga tidak berlaku lagi.
+func (h *Handler) LogoutHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.Denylist.Revoke(ctx.GetString("jti"), userID, ctx.GetTime("token_expires_at"))
+	if err != nil {
+		logrus.Errorf("failed when revoking token: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	sessionID := ctx.GetString("session_id")
+	if _, err := h.TodoRepository.RevokeSession(sessionID, userID); err != nil {
+		logrus.Errorf("failed when revoking session: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.Sessions.Forget(sessionID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// JWKSHandler mempublikasikan public key (aktif dan retired) supaya service lain bisa memverifikasi token.
+func (h *Handler) JWKSHandler(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, cfg.Keys.JWKS())
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todoGin/cfg"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+func refreshToken(t *testing.T, repo *mocks.TodoRepository, token string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/token/refresh", handler.RefreshTokenHandler)
+
+	req, err := http.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refresh_token": "`+token+`"}`))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	const token = "refresh-lama"
+	stored := &entity.RefreshToken{ID: 3, UserID: 7, FamilyID: "keluarga-1", ExpiresAt: time.Now().Add(time.Hour)}
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetRefreshToken", cfg.HashRefreshToken(token)).Return(stored, nil)
+	repo.On("TouchSession", "keluarga-1", mock.Anything).Return(true, nil)
+	repo.On("GetUserByID", int64(7)).Return(&entity.User{Id: 7, Username: "dina"}, nil)
+	// token baru tetap di family yang sama
+	repo.On("RotateRefreshToken", int64(3), mock.MatchedBy(func(next *entity.RefreshToken) bool {
+		return next.FamilyID == "keluarga-1" && next.TokenHash != cfg.HashRefreshToken(token)
+	})).Return(true, nil)
+
+	w := refreshToken(t, repo, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), token)
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	const token = "refresh-bocor"
+	revokedAt := time.Now().Add(-time.Minute)
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetRefreshToken", cfg.HashRefreshToken(token)).
+		Return(&entity.RefreshToken{ID: 3, UserID: 7, FamilyID: "keluarga-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	repo.On("TouchSession", "keluarga-1", mock.Anything).Return(true, nil)
+	repo.On("RevokeSession", "keluarga-1", int64(7)).Return(int64(1), nil)
+
+	w := refreshToken(t, repo, token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	repo.AssertNumberOfCalls(t, "RotateRefreshToken", 0)
+}
+
+func TestRefreshTokenLostRace(t *testing.T) {
+	// request lain sudah merotasi token ini di antara pengecekan dan rotasi
+	const token = "refresh-balapan"
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetRefreshToken", cfg.HashRefreshToken(token)).
+		Return(&entity.RefreshToken{ID: 3, UserID: 7, FamilyID: "keluarga-1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repo.On("TouchSession", "keluarga-1", mock.Anything).Return(true, nil)
+	repo.On("GetUserByID", int64(7)).Return(&entity.User{Id: 7, Username: "dina"}, nil)
+	repo.On("RotateRefreshToken", int64(3), mock.Anything).Return(false, nil)
+	repo.On("RevokeSession", "keluarga-1", int64(7)).Return(int64(1), nil)
+
+	w := refreshToken(t, repo, token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"os"
+	"strings"
+	"time"
+	"todoGin/model/entity"
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxMFAAttempts: setelah sekian kode salah berturut-turut, verifikasi 2FA user dikunci selama mfaLockout.
+	// Batasnya per user, jadi berlaku untuk /login/2fa, /password-reset, dan route 2FA sekaligus.
+	maxMFAAttempts = 5
+	mfaLockout     = 15 * time.Minute
+	totpPeriod     = 30
+)
+
+var errMFALocked = errors.New("too many invalid codes, try again later")
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "todoGin"
+}
+
+// verifySecondFactor menerima kode TOTP 6 digit atau recovery code (yang langsung ditandai terpakai).
+// Setiap percobaan dihitung per user, errMFALocked dikembalikan kalau user sedang dikunci.
+func (h *Handler) verifySecondFactor(user *entity.User, code string) (bool, error) {
+	now := time.Now()
+	allowed, err := h.TodoRepository.StartMFAAttempt(user.Id, now, maxMFAAttempts, now.Add(mfaLockout))
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		return false, errMFALocked
+	}
+
+	valid, err := h.checkSecondFactor(user, strings.TrimSpace(code), now)
+	if err != nil || !valid {
+		return false, err
+	}
+	return true, h.TodoRepository.ResetMFAFailures(user.Id)
+}
+
+func (h *Handler) checkSecondFactor(user *entity.User, code string, now time.Time) (bool, error) {
+	if user.TOTPSecret == nil || code == "" {
+		return false, nil
+	}
+	if step, ok := matchTOTPStep(*user.TOTPSecret, code, now); ok {
+		return h.TodoRepository.AcceptTOTPStep(user.Id, step)
+	}
+	return h.TodoRepository.UseRecoveryCode(user.Id, hashRecoveryCode(code))
+}
+
+// matchTOTPStep mencari time step yang cocok dengan kode, dengan toleransi satu step ke depan dan belakang.
+func matchTOTPStep(secret, code string, now time.Time) (int64, bool) {
+	opts := totp.ValidateOpts{
+		Period:    totpPeriod,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+	for _, skew := range []int64{0, -1, 1} {
+		at := now.Add(time.Duration(skew*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, at, opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// generateRecoveryCodes membuat recovery code format xxxx-xxxx beserta hash-nya.
+func generateRecoveryCodes(n int) (codes, hashes []string, err error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := 0; i < n; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(buf))
+		code := raw[:4] + "-" + raw[4:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode tidak membedakan huruf besar kecil, spasi, dan tanda hubung.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
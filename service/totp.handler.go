@@ -0,0 +1,353 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/pquerna/otp/totp"
+	"github.com/sirupsen/logrus"
+	"image/png"
+	"net/http"
+	"time"
+	"todoGin/cfg"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+// loadCurrentUser mengambil user dari token untuk route 2FA.
+func (h *Handler) loadCurrentUser(ctx *gin.Context) (*entity.User, bool) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	user, err := h.TodoRepository.GetUserByID(userID)
+	if err != nil || user == nil {
+		logrus.Errorf("failed when get user by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	return user, true
+}
+
+func (h *Handler) TOTPHandlerStatus(ctx *gin.Context) {
+	user, ok := h.loadCurrentUser(ctx)
+	if !ok {
+		return
+	}
+
+	var left int64
+	if user.TOTPEnabledAt != nil {
+		var err error
+		left, err = h.TodoRepository.CountRecoveryCodes(user.Id)
+		if err != nil {
+			logrus.Errorf("failed when counting recovery codes: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get 2FA Status",
+		Data: request.TOTPStatusResponse{
+			Enabled:           user.TOTPEnabledAt != nil,
+			RecoveryCodesLeft: left,
+		},
+	})
+}
+
+// TOTPHandlerEnroll membuat secret baru. 2FA belum aktif sampai kode pertama dikonfirmasi.
+func (h *Handler) TOTPHandlerEnroll(ctx *gin.Context) {
+	user, ok := h.loadCurrentUser(ctx)
+	if !ok {
+		return
+	}
+	if user.TOTPEnabledAt != nil {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "2FA is already enabled",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer(),
+		AccountName: user.Username,
+	})
+	if err != nil {
+		logrus.Errorf("failed when generating totp secret: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	var qr bytes.Buffer
+	img, err := key.Image(256, 256)
+	if err == nil {
+		err = png.Encode(&qr, img)
+	}
+	if err != nil {
+		logrus.Errorf("failed when rendering totp qr code: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if err := h.TodoRepository.SetTOTPSecret(user.Id, key.Secret()); err != nil {
+		logrus.Errorf("failed when saving totp secret: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Scan the QR code, then confirm with a code from your authenticator app",
+		Data: request.TOTPEnrollResponse{
+			Secret: key.Secret(),
+			URI:    key.URL(),
+			QRPNG:  base64.StdEncoding.EncodeToString(qr.Bytes()),
+		},
+	})
+}
+
+// TOTPHandlerConfirm mengaktifkan 2FA dan mengembalikan recovery code, hanya ditampilkan sekali.
+func (h *Handler) TOTPHandlerConfirm(ctx *gin.Context) {
+	user, ok := h.loadCurrentUser(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TOTPCodeRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "code is required",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if user.TOTPEnabledAt != nil {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "2FA is already enabled",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+	if user.TOTPSecret == nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Enroll 2FA first",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+	valid, err := h.verifySecondFactor(user, reqBody.Code)
+	if !h.secondFactorOK(ctx, valid, err) {
+		return
+	}
+
+	codes, hashes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err == nil {
+		err = h.TodoRepository.EnableTOTP(user.Id, hashes)
+	}
+	if err != nil {
+		logrus.Errorf("failed when enabling totp: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "2FA enabled, store the recovery codes somewhere safe",
+		Data:    gin.H{"recovery_codes": codes},
+	})
+}
+
+// TOTPHandlerDisable mematikan 2FA, butuh kode TOTP atau recovery code.
+func (h *Handler) TOTPHandlerDisable(ctx *gin.Context) {
+	user, ok := h.loadCurrentUser(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.TOTPCodeRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "code is required",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if user.TOTPEnabledAt == nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "2FA is not enabled",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	valid, err := h.verifySecondFactor(user, reqBody.Code)
+	if !h.secondFactorOK(ctx, valid, err) {
+		return
+	}
+
+	if err := h.TodoRepository.DisableTOTP(user.Id); err != nil {
+		logrus.Errorf("failed when disabling totp: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "2FA disabled",
+	})
+}
+
+// secondFactorOK menulis response untuk kode 2FA yang salah atau user yang sedang dikunci.
+func (h *Handler) secondFactorOK(ctx *gin.Context, valid bool, err error) bool {
+	if errors.Is(err, errMFALocked) {
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, respErr.ErrorResponse{
+			Message: "Too many invalid codes, try again later",
+			Status:  http.StatusTooManyRequests,
+		})
+		return false
+	}
+	if err != nil {
+		logrus.Errorf("failed when verifying second factor: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return false
+	}
+	if !valid {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid code",
+			Status:  http.StatusBadRequest,
+		})
+		return false
+	}
+	return true
+}
+
+// requireSecondFactor dipanggil Login setelah password benar untuk user dengan 2FA aktif.
+func (h *Handler) requireSecondFactor(ctx *gin.Context, user *entity.User) {
+	mfaToken, err := cfg.CreateMFAToken(user.Id, time.Now())
+	if err != nil {
+		logrus.Errorf("failed when generating mfa token: %v", err)
+		ctx.JSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to generate Token",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.MFARequiredResponse{
+		Message:     "Enter the code from your authenticator app",
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresIn:   int64(cfg.MFATokenTTL.Seconds()),
+	})
+}
+
+// LoginMFAHandler adalah langkah kedua login. Token sementara hanya bisa dipakai sekali,
+// percobaan kode dibatasi per user lewat verifySecondFactor.
+func (h *Handler) LoginMFAHandler(ctx *gin.Context) {
+	reqBody := new(request.MFALoginRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.Error{
+			Error: "invalid request Body",
+		})
+		return
+	}
+
+	claims, err := cfg.ParseMFAToken(reqBody.MFAToken)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	isRevoked, err := h.Denylist.IsRevoked(claims.Id)
+	if err != nil {
+		logrus.Errorf("failed when checking token denylist: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to verify code",
+		})
+		return
+	}
+	if isRevoked {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: cfg.ErrInvalidMFAToken.Error(),
+		})
+		return
+	}
+
+	user, err := h.TodoRepository.GetUserByID(claims.UserID)
+	if err != nil {
+		logrus.Errorf("failed when get user by id: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to verify code",
+		})
+		return
+	}
+	if user == nil || user.DisabledAt != nil || user.PasswordResetRequired || user.TOTPEnabledAt == nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: cfg.ErrInvalidMFAToken.Error(),
+		})
+		return
+	}
+
+	valid, err := h.verifySecondFactor(user, reqBody.Code)
+	if errors.Is(err, errMFALocked) {
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, respErr.Error{
+			Error: errMFALocked.Error(),
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when verifying second factor: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to verify code",
+		})
+		return
+	}
+	if !valid {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, respErr.Error{
+			Error: "invalid code",
+		})
+		return
+	}
+
+	// token sementara hanya bisa dipakai sekali
+	if err := h.Denylist.Revoke(claims.Id, user.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		logrus.Errorf("failed when revoking mfa token: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.Error{
+			Error: "Failed to verify code",
+		})
+		return
+	}
+	h.completeLogin(ctx, user)
+}
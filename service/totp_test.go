@@ -0,0 +1,137 @@
+package service
+
+import (
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+	"time"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func totpUser() *entity.User {
+	secret := testTOTPSecret
+	enabledAt := time.Now()
+	return &entity.User{Id: 7, Username: "dina", TOTPSecret: &secret, TOTPEnabledAt: &enabledAt}
+}
+
+// allowAttempt menyiapkan StartMFAAttempt untuk user 7.
+func allowAttempt(repo *mocks.TodoRepository, allowed bool) {
+	repo.On("StartMFAAttempt", int64(7), mock.Anything, maxMFAAttempts, mock.Anything).Return(allowed, nil).Once()
+}
+
+func TestVerifySecondFactorTOTP(t *testing.T) {
+	now := time.Now()
+	code, err := totp.GenerateCode(testTOTPSecret, now)
+	require.NoError(t, err)
+	step := now.Unix() / totpPeriod
+
+	// kode TOTP yang benar tidak menyentuh recovery code
+	repo := mocks.NewTodoRepository(t)
+	allowAttempt(repo, true)
+	repo.On("AcceptTOTPStep", int64(7), mock.AnythingOfType("int64")).Return(true, nil).Once()
+	repo.On("ResetMFAFailures", int64(7)).Return(nil).Once()
+	ok, err := NewTodoService(repo).verifySecondFactor(totpUser(), " "+code+" ")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	accepted := repo.Calls[1].Arguments.Get(1).(int64)
+	assert.InDelta(t, step, accepted, 1)
+
+	// kode yang sama dipakai ulang, step-nya sudah tercatat
+	repo = mocks.NewTodoRepository(t)
+	allowAttempt(repo, true)
+	repo.On("AcceptTOTPStep", int64(7), mock.AnythingOfType("int64")).Return(false, nil).Once()
+	ok, err = NewTodoService(repo).verifySecondFactor(totpUser(), code)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	repo.AssertNumberOfCalls(t, "ResetMFAFailures", 0)
+}
+
+func TestVerifySecondFactorRecoveryCode(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	allowAttempt(repo, true)
+	repo.On("UseRecoveryCode", int64(7), hashRecoveryCode("abcd-efgh")).Return(true, nil).Once()
+	repo.On("ResetMFAFailures", int64(7)).Return(nil).Once()
+
+	// penulisan recovery code boleh beda huruf besar kecil dan tanpa tanda hubung
+	ok, err := NewTodoService(repo).verifySecondFactor(totpUser(), "ABCDEFGH")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestVerifySecondFactorLocked(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	allowAttempt(repo, false)
+
+	ok, err := NewTodoService(repo).verifySecondFactor(totpUser(), "123456")
+	assert.ErrorIs(t, err, errMFALocked)
+	assert.False(t, ok)
+	repo.AssertNumberOfCalls(t, "UseRecoveryCode", 0)
+	repo.AssertNumberOfCalls(t, "AcceptTOTPStep", 0)
+}
+
+func TestVerifySecondFactorWithoutSecret(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	handler := NewTodoService(repo)
+
+	allowAttempt(repo, true)
+	ok, err := handler.verifySecondFactor(&entity.User{Id: 7}, "123456")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	allowAttempt(repo, true)
+	ok, err = handler.verifySecondFactor(totpUser(), "   ")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	repo.AssertNumberOfCalls(t, "UseRecoveryCode", 0)
+	repo.AssertNumberOfCalls(t, "ResetMFAFailures", 0)
+}
+
+func TestMatchTOTPStep(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	codeAt := func(at time.Time) string {
+		code, err := totp.GenerateCode(testTOTPSecret, at)
+		require.NoError(t, err)
+		return code
+	}
+	period := time.Duration(totpPeriod) * time.Second
+
+	step, ok := matchTOTPStep(testTOTPSecret, codeAt(now), now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/totpPeriod, step)
+
+	step, ok = matchTOTPStep(testTOTPSecret, codeAt(now.Add(-period)), now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/totpPeriod-1, step)
+
+	step, ok = matchTOTPStep(testTOTPSecret, codeAt(now.Add(period)), now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/totpPeriod+1, step)
+
+	_, ok = matchTOTPStep(testTOTPSecret, codeAt(now.Add(-2*period)), now)
+	assert.False(t, ok, "kode dua step lalu sudah kedaluwarsa")
+
+	_, ok = matchTOTPStep("bukan-base32!", codeAt(now), now)
+	assert.False(t, ok)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes(recoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	format := regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}$`)
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Regexp(t, format, code)
+		assert.Equal(t, hashRecoveryCode(code), hashes[i])
+		assert.False(t, seen[code], "duplicate code %s", code)
+		seen[code] = true
+	}
+}
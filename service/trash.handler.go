@@ -0,0 +1,102 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) TrashHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	todos, err := h.repo(ctx).GetTrashByUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get trash: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoResponseToGetAll{
+		Message: "Success Get Trash",
+		UserId:  userID,
+		Data:    len(todos),
+		Todos:   todos,
+	})
+}
+
+func (h *Handler) TrashHandlerRestore(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isRestored, err := h.repo(ctx).RestoreTodo(todoID, userID)
+	if err != nil {
+		logrus.Errorf("failed when restoring todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isRestored == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Todo not found in trash",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	h.recordHistory(todoID, userID, entity.HistoryRestored, nil, nil)
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Restore Todo",
+	})
+}
+
+// TrashHandlerPurge menghapus permanen todo dari trash tanpa menunggu masa retensi.
+func (h *Handler) TrashHandlerPurge(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	todoID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	isPurged, err := h.repo(ctx).PurgeTodo(todoID, userID)
+	if err != nil {
+		logrus.Errorf("failed when purging todo: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isPurged == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Todo not found in trash",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Success Purge Todo",
+	})
+}
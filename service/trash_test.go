@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+)
+
+func serveTrash(t *testing.T, repo *mocks.TodoRepository, method, target string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/trash/:id/restore", withUser, handler.TrashHandlerRestore)
+	router.DELETE("/trash/:id", withUser, handler.TrashHandlerPurge)
+
+	req, err := http.NewRequest(method, target, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTrashRestore(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("RestoreTodo", int64(4), int64(1)).Return(int64(1), nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+	assert.Equal(t, http.StatusOK, serveTrash(t, repo, http.MethodPost, "/trash/4/restore").Code)
+
+	// todo yang tidak ada di trash milik user ini
+	repo = mocks.NewTodoRepository(t)
+	repo.On("RestoreTodo", int64(5), int64(1)).Return(int64(0), nil)
+	assert.Equal(t, http.StatusNotFound, serveTrash(t, repo, http.MethodPost, "/trash/5/restore").Code)
+}
+
+func TestTrashPurge(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("PurgeTodo", int64(4), int64(1)).Return(int64(1), nil)
+	assert.Equal(t, http.StatusOK, serveTrash(t, repo, http.MethodDelete, "/trash/4").Code)
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("PurgeTodo", int64(5), int64(1)).Return(int64(0), nil)
+	assert.Equal(t, http.StatusNotFound, serveTrash(t, repo, http.MethodDelete, "/trash/5").Code)
+
+	repo = mocks.NewTodoRepository(t)
+	repo.On("PurgeTodo", int64(6), int64(1)).Return(int64(0), errors.New("s3 client is not configured"))
+	assert.Equal(t, http.StatusInternalServerError, serveTrash(t, repo, http.MethodDelete, "/trash/6").Code)
+}
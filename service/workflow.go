@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"todoGin/model/entity"
+)
+
+// Workflow memetakan setiap status ke daftar status tujuan yang diizinkan.
+type Workflow map[string][]string
+
+// DefaultWorkflow adalah alur kerja bawaan kalau TODO_WORKFLOW tidak di-set.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		entity.StatusBacklog:    {entity.StatusTodo, entity.StatusCancelled},
+		entity.StatusTodo:       {entity.StatusBacklog, entity.StatusInProgress, entity.StatusDone, entity.StatusCancelled},
+		entity.StatusInProgress: {entity.StatusTodo, entity.StatusBlocked, entity.StatusDone, entity.StatusCancelled},
+		entity.StatusBlocked:    {entity.StatusInProgress, entity.StatusCancelled},
+		entity.StatusDone:       {entity.StatusTodo},
+		entity.StatusCancelled:  {entity.StatusBacklog, entity.StatusTodo},
+	}
+}
+
+// ParseWorkflow membaca alur kerja dengan format
+// "backlog=todo,cancelled;todo=in_progress,done;...".
+// Status yang tidak disebut tidak punya transisi keluar.
+func ParseWorkflow(spec string) (Workflow, error) {
+	workflow := Workflow{}
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		from, targets, found := strings.Cut(rule, "=")
+		from = strings.TrimSpace(from)
+		if !found || !isKnownStatus(from) {
+			return nil, fmt.Errorf("invalid workflow rule %q", rule)
+		}
+
+		for _, to := range strings.Split(targets, ",") {
+			to = strings.TrimSpace(to)
+			if !isKnownStatus(to) {
+				return nil, fmt.Errorf("unknown status %q in workflow rule %q", to, rule)
+			}
+			workflow[from] = append(workflow[from], to)
+		}
+	}
+
+	return workflow, nil
+}
+
+// CanTransition mengecek apakah perpindahan status from -> to diizinkan.
+func (w Workflow) CanTransition(from, to string) bool {
+	for _, allowed := range w[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownStatus(status string) bool {
+	for _, s := range entity.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
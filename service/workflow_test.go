@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+	"todoGin/repository"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Workflow
+		wantErr bool
+	}{
+		{"Empty", "", Workflow{}, false},
+		{"SingleRule", "todo=done", Workflow{entity.StatusTodo: {entity.StatusDone}}, false},
+		{"SpacesAndTrailingSeparator", " backlog = todo , cancelled ; todo=in_progress,done; ", Workflow{
+			entity.StatusBacklog: {entity.StatusTodo, entity.StatusCancelled},
+			entity.StatusTodo:    {entity.StatusInProgress, entity.StatusDone},
+		}, false},
+		{"MissingEquals", "todo", nil, true},
+		{"UnknownFrom", "archived=todo", nil, true},
+		{"UnknownTarget", "todo=archived", nil, true},
+		{"EmptyTarget", "todo=", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkflow(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	workflow := DefaultWorkflow()
+
+	// setiap status punya jalan keluar, jadi tidak ada todo yang terjebak
+	for _, status := range entity.Statuses {
+		assert.NotEmpty(t, workflow[status], "status %s has no outgoing transition", status)
+	}
+
+	assert.True(t, workflow.CanTransition(entity.StatusInProgress, entity.StatusDone))
+	assert.True(t, workflow.CanTransition(entity.StatusDone, entity.StatusTodo))
+	assert.False(t, workflow.CanTransition(entity.StatusBacklog, entity.StatusDone))
+	assert.False(t, workflow.CanTransition(entity.StatusBlocked, entity.StatusDone))
+	assert.False(t, workflow.CanTransition("archived", entity.StatusTodo))
+}
+
+func putTodo(t *testing.T, handler *Handler, body string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.PUT("/manage-todo/todo/:id", withUser, handler.TodolistHandlerUpdate)
+
+	req, err := http.NewRequest(http.MethodPut, "/manage-todo/todo/1", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateRejectsIllegalTransition(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Status: entity.StatusBacklog, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+
+	// backlog -> done tidak ada di workflow bawaan
+	w := putTodo(t, NewTodoService(repo), `{"title": "Rilis", "status": "done"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	repo.AssertNumberOfCalls(t, "Update", 0)
+	repo.AssertNumberOfCalls(t, "ChangeStatus", 0)
+}
+
+func TestUpdateStatusRaceSavesNothing(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Status: entity.StatusTodo, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	// request lain sudah mengubah status duluan
+	repo.On("ChangeStatus", int64(1), entity.StatusTodo, entity.StatusInProgress, mock.Anything, (*entity.Todolist)(nil)).
+		Return(nil, repository.ErrConflict)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Rilis", "status": "in_progress"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	// judul ikut ditolak karena disimpan di transaksi yang sama dengan status
+	repo.AssertNumberOfCalls(t, "Update", 0)
+	repo.AssertNumberOfCalls(t, "AddHistory", 0)
+}
+
+func TestUpdateTitleOnlyKeepsStatus(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Status: entity.StatusInProgress, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("Update", int64(1), int64(1), mock.MatchedBy(func(updates map[string]interface{}) bool {
+		_, hasStatus := updates["status"]
+		return updates["title"] == "Judul baru" && !hasStatus
+	})).Return(&entity.Todolist{ID: 1, Title: "Judul baru", Status: entity.StatusInProgress}, nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+
+	w := putTodo(t, NewTodoService(repo), `{"title": "Judul baru"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertNumberOfCalls(t, "ChangeStatus", 0)
+}
+
+func TestUpdateFollowsConfiguredWorkflow(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, Status: entity.StatusBacklog, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	repo.On("ChangeStatus", int64(1), entity.StatusBacklog, entity.StatusDone, map[string]interface{}{}, (*entity.Todolist)(nil)).
+		Return(&entity.StatusTransition{TodoID: 1, FromStatus: entity.StatusBacklog, ToStatus: entity.StatusDone}, nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+
+	handler := NewTodoService(repo)
+	workflow, err := ParseWorkflow("backlog=done")
+	require.NoError(t, err)
+	handler.Workflow = workflow
+
+	w := putTodo(t, handler, `{"status": "done"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertNumberOfCalls(t, "Update", 0)
+}
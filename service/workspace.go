@@ -0,0 +1,90 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"todoGin/model/entity"
+	"todoGin/model/respErr"
+	"todoGin/repository"
+)
+
+// WorkspaceHeader memilih workspace aktif, tanpa header berarti data pribadi user.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceScope memvalidasi header X-Workspace-ID terhadap keanggotaan user lalu
+// menyimpan workspace_id dan workspace_role di context. Dipasang setelah Authmiddleware.
+func (h *Handler) WorkspaceScope() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(WorkspaceHeader)
+		if header == "" {
+			return
+		}
+
+		workspaceID, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: "Invalid " + WorkspaceHeader,
+				Status:  http.StatusBadRequest,
+			})
+			return
+		}
+
+		userID, ok := userIDFromContext(ctx)
+		if !ok {
+			return
+		}
+
+		member, err := h.TodoRepository.GetWorkspaceMember(workspaceID, userID)
+		if err != nil {
+			logrus.Errorf("failed when get workspace member: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+				Message: "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+			})
+			return
+		}
+		if member == nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+				Message: "You are not a member of this workspace",
+				Status:  http.StatusForbidden,
+			})
+			return
+		}
+
+		// guest hanya boleh membaca isi workspace
+		if member.Role == entity.WorkspaceRoleGuest && ctx.Request.Method != http.MethodGet {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+				Message: "Guests have read-only access to this workspace",
+				Status:  http.StatusForbidden,
+			})
+			return
+		}
+
+		ctx.Set("workspace_id", workspaceID)
+		ctx.Set("workspace_role", member.Role)
+	}
+}
+
+// PersonalOnly menolak route yang datanya selalu milik pribadi user (misalnya label)
+// kalau request dikirim dengan header X-Workspace-ID.
+func (h *Handler) PersonalOnly() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader(WorkspaceHeader) != "" {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+				Message: "This route is not available inside a workspace",
+				Status:  http.StatusBadRequest,
+			})
+		}
+	}
+}
+
+// repo mengembalikan repository yang sudah dibatasi ke workspace aktif dari WorkspaceScope.
+func (h *Handler) repo(ctx *gin.Context) repository.TodoRepository {
+	workspaceID, ok := ctx.Get("workspace_id")
+	if !ok {
+		return h.TodoRepository
+	}
+	return h.TodoRepository.InWorkspace(workspaceID.(int64), ctx.GetString("workspace_role"))
+}
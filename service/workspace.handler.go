@@ -0,0 +1,413 @@
+package service
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"todoGin/model/entity"
+	"todoGin/model/request"
+	"todoGin/model/respErr"
+)
+
+func (h *Handler) WorkspaceHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	workspaces, err := h.TodoRepository.GetWorkspacesByUser(userID)
+	if err != nil {
+		logrus.Errorf("failed when get workspaces: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Workspaces",
+		Data:    workspaces,
+	})
+}
+
+func (h *Handler) WorkspaceHandlerCreate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.WorkspaceCreateRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	workspace := &entity.Workspace{
+		Name:    reqBody.Name,
+		OwnerID: userID,
+	}
+	if err := h.TodoRepository.CreateWorkspace(workspace); err != nil {
+		logrus.Errorf("failed when creating workspace: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "New Workspace Created",
+		Data:    workspace,
+	})
+}
+
+func (h *Handler) WorkspaceMemberHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadMembership(ctx, workspaceID, userID); !ok {
+		return
+	}
+
+	members, err := h.TodoRepository.GetWorkspaceMembers(workspaceID)
+	if err != nil {
+		logrus.Errorf("failed when get workspace members: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Workspace Members",
+		Data:    members,
+	})
+}
+
+// WorkspaceInviteHandler mengundang user lewat username, hanya owner dan admin yang boleh.
+func (h *Handler) WorkspaceInviteHandler(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.WorkspaceInviteRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadMembership(ctx, workspaceID, userID, entity.WorkspaceRoleOwner, entity.WorkspaceRoleAdmin); !ok {
+		return
+	}
+
+	user, err := h.TodoRepository.GetUserByUsername(reqBody.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "User not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("failed when get user by username: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	existing, err := h.TodoRepository.GetWorkspaceMember(workspaceID, user.Id)
+	if err != nil {
+		logrus.Errorf("failed when get workspace member: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if existing != nil {
+		ctx.AbortWithStatusJSON(http.StatusConflict, respErr.ErrorResponse{
+			Message: "User is already a member of this workspace",
+			Status:  http.StatusConflict,
+		})
+		return
+	}
+
+	invitation := &entity.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		UserID:      user.Id,
+		Role:        reqBody.Role,
+		InvitedBy:   userID,
+	}
+	if err := h.TodoRepository.CreateWorkspaceInvitation(invitation); err != nil {
+		logrus.Errorf("failed when creating workspace invitation: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Invitation sent",
+		Data:    invitation,
+	})
+}
+
+// WorkspaceMemberHandlerUpdate mengganti role anggota, hanya owner yang boleh.
+func (h *Handler) WorkspaceMemberHandlerUpdate(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := paramID(ctx, "user_id")
+	if !ok {
+		return
+	}
+
+	reqBody := new(request.WorkspaceMemberRoleRequest)
+	if err := ctx.ShouldBindJSON(reqBody); err != nil {
+		logrus.Error(err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "Invalid input",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, ok := h.loadMembership(ctx, workspaceID, userID, entity.WorkspaceRoleOwner); !ok {
+		return
+	}
+	if memberID == userID {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "The workspace owner cannot change their own role",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	isUpdated, err := h.TodoRepository.UpdateWorkspaceMemberRole(workspaceID, memberID, reqBody.Role)
+	if err != nil {
+		logrus.Errorf("failed when updating workspace member: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if isUpdated == 0 {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Member not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Update Member",
+		Data:    gin.H{"workspace_id": workspaceID, "user_id": memberID, "role": reqBody.Role},
+	})
+}
+
+// WorkspaceMemberHandlerDelete mengeluarkan anggota. Owner dan admin bisa mengeluarkan
+// anggota di bawahnya, anggota lain hanya bisa keluar sendiri. Owner tidak bisa keluar.
+func (h *Handler) WorkspaceMemberHandlerDelete(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+	memberID, ok := paramID(ctx, "user_id")
+	if !ok {
+		return
+	}
+
+	self, ok := h.loadMembership(ctx, workspaceID, userID)
+	if !ok {
+		return
+	}
+
+	target, err := h.TodoRepository.GetWorkspaceMember(workspaceID, memberID)
+	if err != nil {
+		logrus.Errorf("failed when get workspace member: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if target == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Member not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+	if target.Role == entity.WorkspaceRoleOwner {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, respErr.ErrorResponse{
+			Message: "The workspace owner cannot be removed",
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+	if memberID != userID && !outranks(self.Role, target.Role) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+			Message: "You cannot remove this member",
+			Status:  http.StatusForbidden,
+		})
+		return
+	}
+
+	if _, err := h.TodoRepository.RemoveWorkspaceMember(workspaceID, memberID); err != nil {
+		logrus.Errorf("failed when removing workspace member: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.TodoDeleteResponse{
+		Status:  http.StatusOK,
+		Message: "Member removed",
+	})
+}
+
+func (h *Handler) InvitationHandlerGetAll(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.TodoRepository.GetPendingInvitations(userID)
+	if err != nil {
+		logrus.Errorf("failed when get invitations: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Success Get Invitations",
+		Data:    invitations,
+	})
+}
+
+func (h *Handler) InvitationHandlerAccept(ctx *gin.Context) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	invitationID, ok := paramID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	member, err := h.TodoRepository.AcceptWorkspaceInvitation(invitationID, userID)
+	if err != nil {
+		logrus.Errorf("failed when accepting invitation: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if member == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Invitation not found",
+			Status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request.SuccessMessage{
+		Status:  http.StatusOK,
+		Message: "Invitation accepted",
+		Data:    member,
+	})
+}
+
+// loadMembership memastikan user anggota workspace, dan kalau roles diisi,
+// role-nya harus salah satu dari roles.
+func (h *Handler) loadMembership(ctx *gin.Context, workspaceID, userID int64, roles ...string) (*entity.WorkspaceMember, bool) {
+	member, err := h.TodoRepository.GetWorkspaceMember(workspaceID, userID)
+	if err != nil {
+		logrus.Errorf("failed when get workspace member: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, respErr.ErrorResponse{
+			Message: "Internal Server Error",
+			Status:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	if member == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, respErr.ErrorResponse{
+			Message: "Workspace not found",
+			Status:  http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if len(roles) == 0 {
+		return member, true
+	}
+	for _, role := range roles {
+		if member.Role == role {
+			return member, true
+		}
+	}
+
+	ctx.AbortWithStatusJSON(http.StatusForbidden, respErr.ErrorResponse{
+		Message: "Your workspace role does not allow this",
+		Status:  http.StatusForbidden,
+	})
+	return nil, false
+}
+
+// outranks: owner di atas admin, admin di atas member dan guest.
+func outranks(role, other string) bool {
+	rank := map[string]int{
+		entity.WorkspaceRoleOwner:  3,
+		entity.WorkspaceRoleAdmin:  2,
+		entity.WorkspaceRoleMember: 1,
+		entity.WorkspaceRoleGuest:  1,
+	}
+	return rank[role] > rank[other]
+}
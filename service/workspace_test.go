@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todoGin/mocks"
+	"todoGin/model/entity"
+)
+
+// serveWorkspace menjalankan request lewat WorkspaceScope seperti group auth di router.
+func serveWorkspace(t *testing.T, repo *mocks.TodoRepository, method, target, workspace string) *httptest.ResponseRecorder {
+	handler := NewTodoService(repo)
+	router := gin.New()
+	scoped := router.Group("/", withUser, handler.WorkspaceScope())
+	scoped.GET("/manage-todo/todo/:id", handler.TodolistHandlerGetByID)
+	scoped.PUT("/manage-todo/todo/:id", handler.TodolistHandlerUpdate)
+	scoped.DELETE("/manage-todo/todo/:id", handler.TodolistHandlerDelete)
+	scoped.POST("/manage-todo", handler.TodolistHandlerCreate)
+
+	req, err := http.NewRequest(method, target, bytes.NewBufferString(`{"title": "Dari guest"}`))
+	require.NoError(t, err)
+	if workspace != "" {
+		req.Header.Set(WorkspaceHeader, workspace)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWorkspaceGuestIsReadOnly(t *testing.T) {
+	guest := &entity.WorkspaceMember{WorkspaceID: 5, UserID: 1, Role: entity.WorkspaceRoleGuest}
+
+	t.Run("Read", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+		repo.On("GetWorkspaceMember", int64(5), int64(1)).Return(guest, nil)
+		// handler memakai repository yang dibatasi ke workspace 5 dengan role guest
+		repo.On("InWorkspace", int64(5), entity.WorkspaceRoleGuest).Return(repo)
+		repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 2, AccessRole: entity.ShareRoleViewer}, nil)
+
+		w := serveWorkspace(t, repo, http.MethodGet, "/manage-todo/todo/1", "5")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	for _, write := range []struct{ method, target string }{
+		{http.MethodPost, "/manage-todo"},
+		{http.MethodPut, "/manage-todo/todo/1"},
+		{http.MethodDelete, "/manage-todo/todo/1"},
+	} {
+		t.Run(write.method, func(t *testing.T) {
+			repo := mocks.NewTodoRepository(t)
+			repo.On("GetWorkspaceMember", int64(5), int64(1)).Return(guest, nil)
+
+			w := serveWorkspace(t, repo, write.method, write.target, "5")
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			// ditolak sebelum handler jalan
+			repo.AssertNumberOfCalls(t, "InWorkspace", 0)
+		})
+	}
+}
+
+func TestWorkspaceScopeMembership(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetWorkspaceMember", int64(6), int64(1)).Return(nil, nil)
+	assert.Equal(t, http.StatusForbidden, serveWorkspace(t, repo, http.MethodGet, "/manage-todo/todo/1", "6").Code)
+
+	repo = mocks.NewTodoRepository(t)
+	assert.Equal(t, http.StatusBadRequest, serveWorkspace(t, repo, http.MethodGet, "/manage-todo/todo/1", "tim").Code)
+
+	// tanpa header tetap memakai data pribadi user
+	repo = mocks.NewTodoRepository(t)
+	repo.On("GetByID", int64(1), int64(1)).Return(&entity.Todolist{ID: 1, UserID: 1, AccessRole: entity.AccessOwner}, nil)
+	assert.Equal(t, http.StatusOK, serveWorkspace(t, repo, http.MethodGet, "/manage-todo/todo/1", "").Code)
+	repo.AssertNumberOfCalls(t, "GetWorkspaceMember", 0)
+	repo.AssertNumberOfCalls(t, "InWorkspace", 0)
+}
+
+func TestWorkspaceAdminManagesTodo(t *testing.T) {
+	admin := &entity.WorkspaceMember{WorkspaceID: 5, UserID: 1, Role: entity.WorkspaceRoleAdmin}
+	// todo dibuat user 2, admin workspace mendapat akses manager
+	managed := &entity.Todolist{ID: 1, UserID: 2, AccessRole: entity.AccessManager}
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetWorkspaceMember", int64(5), int64(1)).Return(admin, nil)
+	repo.On("InWorkspace", int64(5), entity.WorkspaceRoleAdmin).Return(repo)
+	repo.On("GetByID", int64(1), int64(1)).Return(managed, nil)
+	repo.On("Delete", int64(1), int64(2)).Return(int64(1), nil)
+	repo.On("AddHistory", mock.Anything).Return(nil)
+
+	w := serveWorkspace(t, repo, http.MethodDelete, "/manage-todo/todo/1", "5")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// share tetap hanya untuk pembuat todo
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.POST("/manage-todo/todo/:id/shares", withUser, handler.TodoShareHandlerCreate)
+	req, err := http.NewRequest(http.MethodPost, "/manage-todo/todo/1/shares", bytes.NewBufferString(`{"username": "budi", "role": "viewer"}`))
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertNumberOfCalls(t, "ShareTodo", 0)
+}
+
+func TestPersonalOnly(t *testing.T) {
+	repo := mocks.NewTodoRepository(t)
+	repo.On("GetLabelsByUser", int64(1)).Return([]entity.Label{}, nil)
+	handler := NewTodoService(repo)
+	router := gin.New()
+	router.GET("/labels", withUser, handler.PersonalOnly(), handler.LabelHandlerGetAll)
+
+	get := func(workspace string) int {
+		req, err := http.NewRequest(http.MethodGet, "/labels", nil)
+		require.NoError(t, err)
+		if workspace != "" {
+			req.Header.Set(WorkspaceHeader, workspace)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get("5"))
+	repo.AssertNumberOfCalls(t, "GetLabelsByUser", 0)
+	assert.Equal(t, http.StatusOK, get(""))
+}